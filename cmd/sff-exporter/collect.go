@@ -0,0 +1,212 @@
+package main
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/bluecmd/go-sff"
+	"github.com/bluecmd/go-sff/common"
+)
+
+// target is a module read on every scrape, port is used as the port label
+type target struct {
+	port   string
+	reader sff.Reader
+}
+
+// laneValues holds the monitors of a lane in mA and mW
+type laneValues struct {
+	lane                                    int
+	txBias, txPower, rxPower                float64
+	txBiasState, txPowerState, rxPowerState common.AlarmState
+}
+
+// monitors holds the module and lane monitors independent of the module type
+type monitors struct {
+	temperature, vcc           float64
+	temperatureState, vccState common.AlarmState
+	lanes                      []laneValues
+}
+
+// thresholds holds high alarm, low alarm, high warning and low warning
+type thresholds [4]float64
+
+var levels = [4]string{"high_alarm", "low_alarm", "high_warning", "low_warning"}
+
+var levelStates = [4]common.AlarmState{
+	common.AlarmStateHighAlarm, common.AlarmStateLowAlarm,
+	common.AlarmStateHighWarning, common.AlarmStateLowWarning,
+}
+
+// with returns a copy of base with l appended
+func with(base []label, l ...label) []label {
+	return append(append(make([]label, 0, len(base)+len(l)), base...), l...)
+}
+
+// collect reads the module of t and adds its metrics to r. The returned
+// error is also reported as sff_up 0.
+func collect(r *registry, t target) error {
+	port := label{"port", t.port}
+	m, err := sff.Read(t.reader)
+	if err != nil {
+		r.add("sff_up", "Whether the module could be read and decoded.", 0, port)
+		return err
+	}
+	r.add("sff_up", "Whether the module could be read and decoded.", 1, port)
+
+	inv := m.Inventory()
+	base := []label{port, {"vendor", inv.Vendor}, {"pn", inv.PartNumber}, {"sn", inv.SerialNumber}}
+	r.add("sff_module_info", "Module identification, the value is always 1.", 1, with(base,
+		label{"type", string(m.Type)},
+		label{"identifier", inv.Identifier.ShortName()},
+		label{"rev", inv.Revision},
+		label{"media_type", inv.MediaType.String()},
+	)...)
+
+	mon, th := moduleMonitors(m)
+	if mon == nil {
+		return nil
+	}
+	r.add("sff_temperature_celsius", "Module temperature in degrees Celsius.", mon.temperature, base...)
+	r.add("sff_vcc_volts", "Module supply voltage in volts.", mon.vcc, base...)
+	addAlarm(r, with(base, label{"monitor", "temperature"}), mon.temperatureState)
+	addAlarm(r, with(base, label{"monitor", "vcc"}), mon.vccState)
+
+	for _, l := range mon.lanes {
+		lb := with(base, label{"lane", strconv.Itoa(l.lane)})
+		r.add("sff_tx_bias_milliamps", "Laser bias current in mA.", l.txBias, lb...)
+		r.add("sff_tx_power_milliwatts", "Transmit optical power in mW.", l.txPower, lb...)
+		r.add("sff_tx_power_dbm", "Transmit optical power in dBm.", dBm(l.txPower), lb...)
+		r.add("sff_rx_power_milliwatts", "Receive optical power in mW.", l.rxPower, lb...)
+		r.add("sff_rx_power_dbm", "Receive optical power in dBm.", dBm(l.rxPower), lb...)
+		addAlarm(r, with(lb, label{"monitor", "tx_bias"}), l.txBiasState)
+		addAlarm(r, with(lb, label{"monitor", "tx_power"}), l.txPowerState)
+		addAlarm(r, with(lb, label{"monitor", "rx_power"}), l.rxPowerState)
+	}
+
+	if th == nil {
+		return nil
+	}
+	for _, t := range []struct {
+		name, help string
+		values     thresholds
+	}{
+		{"sff_temperature_threshold_celsius", "Module temperature threshold in degrees Celsius.", th.temperature},
+		{"sff_vcc_threshold_volts", "Module supply voltage threshold in volts.", th.vcc},
+		{"sff_tx_bias_threshold_milliamps", "Laser bias current threshold in mA.", th.txBias},
+		{"sff_tx_power_threshold_milliwatts", "Transmit optical power threshold in mW.", th.txPower},
+		{"sff_rx_power_threshold_milliwatts", "Receive optical power threshold in mW.", th.rxPower},
+	} {
+		// Thresholds of zero are not implemented by the module
+		if t.values == (thresholds{}) {
+			continue
+		}
+		for i, v := range t.values {
+			r.add(t.name, t.help, v, with(base, label{"level", levels[i]})...)
+		}
+	}
+	return nil
+}
+
+// addAlarm adds one sff_alarm sample per level, 1 for the level of state.
+// Nothing is added for monitors without thresholds.
+func addAlarm(r *registry, labels []label, state common.AlarmState) {
+	if state == common.AlarmStateNone {
+		return
+	}
+	for i, l := range levels {
+		v := 0.0
+		if state == levelStates[i] {
+			v = 1
+		}
+		r.add("sff_alarm", "Whether the monitor is at the alarm or warning level.", v, with(labels, label{"level", l})...)
+	}
+}
+
+func dBm(mW float64) float64 {
+	return 10 * math.Log10(mW)
+}
+
+// moduleThresholds holds the thresholds of all monitors
+type moduleThresholds struct {
+	temperature, vcc, txBias, txPower, rxPower thresholds
+}
+
+// moduleMonitors returns the monitors of m, or nil if the module has none,
+// and the thresholds, or nil if the module does not provide them
+func moduleMonitors(m *sff.Module) (*monitors, *moduleThresholds) {
+	switch m.Type {
+	case sff.TypeSff8079, sff.TypeSff8636:
+		d := m.Diagnostics()
+		if d == nil {
+			return nil, nil
+		}
+		mon := &monitors{
+			temperature:      d.Temperature.Celsius(),
+			vcc:              d.Vcc.Volts(),
+			temperatureState: d.TemperatureState,
+			vccState:         d.VccState,
+		}
+		for _, l := range d.Lanes {
+			mon.lanes = append(mon.lanes, laneValues{
+				lane:         l.Lane,
+				txBias:       l.TxBias.MilliAmp(),
+				txPower:      l.TxPower.MilliWatt(),
+				rxPower:      l.RxPower.MilliWatt(),
+				txBiasState:  l.TxBiasState,
+				txPowerState: l.TxPowerState,
+				rxPowerState: l.RxPowerState,
+			})
+		}
+		if m.Type == sff.TypeSff8079 {
+			s := m.Sff8079
+			return mon, newThresholds(s.TempThresholds, s.VccThresholds, s.TxBiasThresholds, s.TxPowerThresholds, s.RxPowerThresholds, 1)
+		}
+		if p := m.Sff8636.Page03; p != nil {
+			return mon, newThresholds(p.TempThresholds, p.VccThresholds, p.TxBiasThresholds, p.TxPowerThresholds, p.RxPowerThresholds, 1)
+		}
+		return mon, nil
+
+	case sff.TypeCmis:
+		c := m.Cmis
+		mon := &monitors{
+			temperature:      c.Lower.Temperature.Celsius(),
+			vcc:              c.Lower.Vcc.Volts(),
+			temperatureState: c.Lower.Flags.TemperatureState(),
+			vccState:         c.Lower.Flags.VccState(),
+		}
+		for _, l := range c.Lanes {
+			mon.lanes = append(mon.lanes, laneValues{
+				lane:         l.Lane,
+				txBias:       l.TxBiasMilliAmp,
+				txPower:      l.TxPower.MilliWatt(),
+				rxPower:      l.RxPower.MilliWatt(),
+				txBiasState:  l.TxBiasState,
+				txPowerState: l.TxPowerState,
+				rxPowerState: l.RxPowerState,
+			})
+		}
+		p := c.Page02
+		if p == nil {
+			return mon, nil
+		}
+		biasMultiplier := 1
+		if c.Page01 != nil {
+			biasMultiplier = c.Page01.MonitorSupport.TxBiasMultiplier()
+		}
+		return mon, newThresholds(p.TempThresholds, p.VccThresholds, p.TxBiasThresholds, p.TxPowerThresholds, p.RxPowerThresholds, biasMultiplier)
+	}
+	return nil, nil
+}
+
+func newThresholds(temp common.TemperatureThresholds, vcc common.VoltageThresholds, bias common.CurrentThresholds,
+	tx, rx common.PowerThresholds, biasMultiplier int) *moduleThresholds {
+	b := float64(biasMultiplier)
+	return &moduleThresholds{
+		temperature: thresholds{temp.HighAlarm.Celsius(), temp.LowAlarm.Celsius(), temp.HighWarning.Celsius(), temp.LowWarning.Celsius()},
+		vcc:         thresholds{vcc.HighAlarm.Volts(), vcc.LowAlarm.Volts(), vcc.HighWarning.Volts(), vcc.LowWarning.Volts()},
+		txBias:      thresholds{bias.HighAlarm.MilliAmp() * b, bias.LowAlarm.MilliAmp() * b, bias.HighWarning.MilliAmp() * b, bias.LowWarning.MilliAmp() * b},
+		txPower:     thresholds{tx.HighAlarm.MilliWatt(), tx.LowAlarm.MilliWatt(), tx.HighWarning.MilliWatt(), tx.LowWarning.MilliWatt()},
+		rxPower:     thresholds{rx.HighAlarm.MilliWatt(), rx.LowAlarm.MilliWatt(), rx.HighWarning.MilliWatt(), rx.LowWarning.MilliWatt()},
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bluecmd/go-sff"
+)
+
+func TestScrape(t *testing.T) {
+	e := &exporter{targets: []target{
+		{"eth0", sff.NewFileReader("../../testdata/FLEX-P.8596.02.bin")},
+		{"eth1", sff.NewFileReader("../../testdata/TR-FC85S-N00.bin")},
+		{"eth2", sff.NewFileReader("../../testdata/CMIS-QDD-400G-DR4.bin")},
+		{"eth3", sff.NewFileReader("../../testdata/missing.bin")},
+	}}
+	out := string(e.scrape())
+
+	for _, want := range []string{
+		`sff_up{port="eth0"} 1`,
+		`sff_up{port="eth3"} 0`,
+		`sff_module_info{port="eth1",vendor="INNOLIGHT",pn="TR-FC85S-N00",sn="INKAP3224117",type="SFF-8636",identifier="QSFP28",rev="1A",media_type="Optical Interfaces: MMF"} 1`,
+		`sff_temperature_celsius{port="eth0",vendor="FLEXOPTIX",pn="P.8596.02",sn="F79D002"} 18.40625`,
+		`sff_rx_power_milliwatts{port="eth0",vendor="FLEXOPTIX",pn="P.8596.02",sn="F79D002",lane="1"} 0.6642`,
+		`sff_tx_bias_milliamps{port="eth1",vendor="INNOLIGHT",pn="TR-FC85S-N00",sn="INKAP3224117",lane="2"} 5.468`,
+		`sff_tx_bias_milliamps{port="eth2",vendor="GOSFF TEST",pn="QDD-400G-DR4",sn="CMIS0001",lane="4"} 34.5`,
+		`sff_temperature_threshold_celsius{port="eth0",vendor="FLEXOPTIX",pn="P.8596.02",sn="F79D002",level="high_alarm"} 90`,
+		`sff_alarm{port="eth2",vendor="GOSFF TEST",pn="QDD-400G-DR4",sn="CMIS0001",lane="4",monitor="rx_power",level="low_warning"} 1`,
+		`sff_alarm{port="eth0",vendor="FLEXOPTIX",pn="P.8596.02",sn="F79D002",monitor="temperature",level="high_alarm"} 0`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("Missing %q", want)
+		}
+	}
+
+	// The QSFP fixture has no page 03h thresholds
+	if strings.Contains(out, `sff_rx_power_threshold_milliwatts{port="eth1"`) {
+		t.Error("Unexpected thresholds for module without page 03h")
+	}
+	// Each family is described once
+	if n := strings.Count(out, "# TYPE sff_rx_power_dbm gauge\n"); n != 1 {
+		t.Errorf("Got %d TYPE lines for sff_rx_power_dbm, want 1", n)
+	}
+}
+
+func TestScrapeNoMonitors(t *testing.T) {
+	b, err := os.ReadFile("../../testdata/TR-FC85S-N00.bin")
+	if err != nil {
+		t.Fatalf("Failed to read EEPROM file: %v", err)
+	}
+	dir := t.TempDir()
+	// A page 03h without thresholds
+	zero := filepath.Join(dir, "zero.bin")
+	if err := os.WriteFile(zero, append(b[:512:512], make([]byte, 128)...), 0644); err != nil {
+		t.Fatalf("Failed to write dump: %v", err)
+	}
+	// No monitors advertised in byte 220, like a passive copper cable
+	b = append([]byte{}, b...)
+	b[220] = 0
+	dac := filepath.Join(dir, "dac.bin")
+	if err := os.WriteFile(dac, b, 0644); err != nil {
+		t.Fatalf("Failed to write dump: %v", err)
+	}
+
+	e := &exporter{targets: []target{{"eth0", sff.NewFileReader(zero)}, {"eth1", sff.NewFileReader(dac)}}}
+	out := string(e.scrape())
+	if strings.Contains(out, `_threshold_`) {
+		t.Error("Unexpected thresholds for module without thresholds")
+	}
+	if !strings.Contains(out, `sff_tx_bias_milliamps{port="eth0"`) {
+		t.Error("Missing monitors of module without thresholds")
+	}
+	if strings.Contains(out, `sff_temperature_celsius{port="eth1"`) {
+		t.Error("Unexpected monitors for module without diagnostics")
+	}
+}
+
+func TestRegistryFormat(t *testing.T) {
+	r := newRegistry()
+	r.add("test_value", "Test value.", -1/zero(), label{"name", "a \"b\"\\\n"})
+	var b bytes.Buffer
+	if err := r.write(&b); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	want := "# HELP test_value Test value.\n# TYPE test_value gauge\ntest_value{name=\"a \\\"b\\\"\\\\\\n\"} -Inf\n"
+	if b.String() != want {
+		t.Errorf("Got %q, want %q", b.String(), want)
+	}
+}
+
+func zero() float64 { return 0 }
+
+func TestRunStops(t *testing.T) {
+	e := &exporter{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	done := make(chan struct{})
+	go func() {
+		e.run(ctx, time.Hour)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("run() did not return after cancel")
+	}
+}
@@ -0,0 +1,169 @@
+// Command sff-exporter exposes the diagnostic monitors of SFF modules as
+// Prometheus metrics
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/bluecmd/go-sff"
+)
+
+// listFlag is a flag that may be given multiple times
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+// splitPort splits a "port=source" flag value. Without a port name the source
+// is used as port label.
+func splitPort(v string) (port, source string) {
+	if i := strings.Index(v, "="); i >= 0 {
+		return v[:i], v[i+1:]
+	}
+	return v, v
+}
+
+// exporter reads all targets periodically and serves the latest metrics
+type exporter struct {
+	targets []target
+
+	mu      sync.Mutex
+	metrics []byte
+}
+
+// scrape reads all targets and returns the metrics in the text exposition
+// format
+func (e *exporter) scrape() []byte {
+	r := newRegistry()
+	start := time.Now()
+	for _, t := range e.targets {
+		if err := collect(r, t); err != nil {
+			log.Printf("Failed to read module on %s: %v", t.port, err)
+		}
+	}
+	r.add("sff_scrape_duration_seconds", "Time it took to read all modules.", time.Since(start).Seconds())
+
+	var b bytes.Buffer
+	r.write(&b)
+	return b.Bytes()
+}
+
+func (e *exporter) update() {
+	m := e.scrape()
+	e.mu.Lock()
+	e.metrics = m
+	e.mu.Unlock()
+}
+
+// run updates the metrics every interval until ctx is done
+func (e *exporter) run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			e.update()
+		}
+	}
+}
+
+func (e *exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	m := e.metrics
+	e.mu.Unlock()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(m)
+}
+
+func main() {
+	var devices, interfaces, files listFlag
+	flag.Var(&devices, "device", "I2C device path to read, as [port=]path (repeatable)")
+	flag.Var(&interfaces, "interface", "Network interface to read using ethtool (repeatable)")
+	flag.Var(&files, "file", "File to read EEPROM data from, as [port=]path (repeatable)")
+	var (
+		useNetlink = flag.Bool("netlink", false, "Use ethtool netlink instead of ioctl for -interface")
+		listen     = flag.String("listen", ":9442", "Address to serve metrics on")
+		interval   = flag.Duration("interval", 30*time.Second, "Interval between module reads")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nOptions:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  %s -interface eth0 -interface eth1\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -device port1=/dev/i2c-1 -device port2=/dev/i2c-2\n", os.Args[0])
+	}
+	flag.Parse()
+
+	e := &exporter{}
+	var closers []io.Closer
+	for _, d := range devices {
+		port, path := splitPort(d)
+		e.targets = append(e.targets, target{port: port, reader: sff.NewI2CReader(path)})
+	}
+	for _, ifName := range interfaces {
+		var reader sff.Reader = sff.NewEthtoolReader(ifName)
+		if *useNetlink {
+			nl := sff.NewNetlinkReader(ifName)
+			closers = append(closers, nl)
+			reader = nl
+		}
+		e.targets = append(e.targets, target{port: ifName, reader: reader})
+	}
+	for _, f := range files {
+		port, path := splitPort(f)
+		e.targets = append(e.targets, target{port: port, reader: sff.NewFileReader(path)})
+	}
+	if len(e.targets) == 0 {
+		log.Fatal("No modules to read, specify at least one of -device, -interface and -file")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	e.update()
+	done := make(chan struct{})
+	go func() {
+		e.run(ctx, *interval)
+		close(done)
+	}()
+
+	http.Handle("/metrics", e)
+	srv := &http.Server{Addr: *listen}
+	go func() {
+		<-ctx.Done()
+		srv.Shutdown(context.Background())
+	}()
+	log.Printf("Serving metrics of %d modules on %s/metrics", len(e.targets), *listen)
+	err := srv.ListenAndServe()
+
+	// Close the readers once the last read has finished
+	stop()
+	<-done
+	for _, c := range closers {
+		c.Close()
+	}
+	if err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// label is a Prometheus label name and value
+type label struct {
+	name  string
+	value string
+}
+
+type sample struct {
+	labels []label
+	value  float64
+}
+
+// family is a metric family, written with a single HELP and TYPE line
+type family struct {
+	name    string
+	help    string
+	samples []sample
+}
+
+// registry collects gauge samples per family in the text exposition format
+// (version 0.0.4)
+type registry struct {
+	families map[string]*family
+}
+
+func newRegistry() *registry {
+	return &registry{families: map[string]*family{}}
+}
+
+// add adds a sample to the family name, creating it with help if needed
+func (r *registry) add(name, help string, value float64, labels ...label) {
+	f, ok := r.families[name]
+	if !ok {
+		f = &family{name: name, help: help}
+		r.families[name] = f
+	}
+	f.samples = append(f.samples, sample{labels: labels, value: value})
+}
+
+// write writes all families sorted by name
+func (r *registry) write(w io.Writer) error {
+	names := make([]string, 0, len(r.families))
+	for n := range r.families {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		f := r.families[n]
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", f.name, f.help, f.name); err != nil {
+			return err
+		}
+		for _, s := range f.samples {
+			if _, err := fmt.Fprintf(w, "%s%s %s\n", f.name, formatLabels(s.labels), formatValue(s.value)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatLabels(labels []label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	l := make([]string, len(labels))
+	for i, lb := range labels {
+		l[i] = fmt.Sprintf(`%s="%s"`, lb.name, labelEscaper.Replace(lb.value))
+	}
+	return "{" + strings.Join(l, ",") + "}"
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
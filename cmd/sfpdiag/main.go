@@ -1,21 +1,28 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 
 	"github.com/bluecmd/go-sff"
+	"github.com/bluecmd/go-sff/sff8079"
 )
 
 func main() {
 	var (
 		devicePath = flag.String("device", "/dev/i2c-0", "I2C device path")
 		filePath   = flag.String("file", "", "File path to read EEPROM data from")
-		outputJSON = flag.Bool("json", false, "Output in JSON format")
+		ifName     = flag.String("interface", "", "Network interface to read EEPROM data from using ethtool")
+		useNetlink = flag.Bool("netlink", false, "Use ethtool netlink instead of ioctl for -interface")
+		outputJSON = flag.Bool("json", false, "Output in JSON format, same as -format json")
+		format     = flag.String("format", "text", "Output format: text, json or ethtool (the layout of ethtool -m)")
 		outputCol  = flag.Bool("color", false, "Output with colors")
+		watchEvery = flag.Duration("watch", 0, "Re-read the diagnostic monitors at this interval and show them live")
 		help       = flag.Bool("help", false, "Show help")
 	)
 	flag.Parse()
@@ -27,20 +34,49 @@ func main() {
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
 		fmt.Fprintf(os.Stderr, "  %s -device /dev/i2c-1\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -file /path/to/eeprom.bin\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -interface eth0\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -interface eth0 -netlink\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -json\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -color\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -interface eth0 -format ethtool\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -interface eth0 -watch 1s -color\n", os.Args[0])
 		os.Exit(0)
 	}
 
-	// Validate that either device or file is specified, but not both
-	if *devicePath != "/dev/i2c-0" && *filePath != "" {
-		log.Fatal("Cannot specify both -device and -file flags")
+	if *outputJSON {
+		*format = "json"
+	}
+	switch *format {
+	case "text", "json", "ethtool":
+	default:
+		log.Fatalf("Unknown output format %q, expected text, json or ethtool", *format)
+	}
+
+	// Validate that only one of device, file or interface is specified
+	sources := 0
+	if *devicePath != "/dev/i2c-0" {
+		sources++
+	}
+	if *filePath != "" {
+		sources++
+	}
+	if *ifName != "" {
+		sources++
+	}
+	if sources > 1 {
+		log.Fatal("Cannot specify more than one of -device, -file and -interface flags")
 	}
 
 	// Create appropriate reader based on flags
 	var reader sff.Reader
 	if *filePath != "" {
 		reader = sff.NewFileReader(*filePath)
+	} else if *ifName != "" && *useNetlink {
+		nl := sff.NewNetlinkReader(*ifName)
+		defer nl.Close()
+		reader = nl
+	} else if *ifName != "" {
+		reader = sff.NewEthtoolReader(*ifName)
 	} else {
 		reader = sff.NewI2CReader(*devicePath)
 	}
@@ -51,14 +87,33 @@ func main() {
 		log.Fatalf("Failed to read transceiver: %v", err)
 	}
 
+	if *watchEvery > 0 {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		if err := watch(ctx, module, reader, *watchEvery, *outputCol); err != nil {
+			log.Fatalf("Failed to watch transceiver: %v", err)
+		}
+		return
+	}
+
 	// Output based on flags
-	if *outputJSON {
-		// JSON output
+	if *format == "ethtool" {
+		// Same field names and layout as ethtool -m, for tools parsing it
+		out, err := module.EthtoolString()
+		if err != nil {
+			log.Fatalf("Failed to format transceiver: %v", err)
+		}
+		fmt.Print(out)
+		return
+	} else if *format == "json" {
+		// JSON output, without the summary so it can be read back with
+		// json.Unmarshal into an sff.Module
 		data, err := json.MarshalIndent(module, "", "  ")
 		if err != nil {
 			log.Fatalf("Failed to marshal JSON: %v", err)
 		}
 		fmt.Println(string(data))
+		return
 	} else if *outputCol {
 		// Colored output
 		fmt.Println(module.StringCol())
@@ -71,26 +126,52 @@ func main() {
 	fmt.Printf("\n=== Summary ===\n")
 	fmt.Printf("Module Type: %s\n", module.Type)
 
+	if module.Type == sff.TypeUnknown {
+		fmt.Printf("Unknown module type\n")
+	} else {
+		inv := module.Inventory()
+		fmt.Printf("Vendor: %s\n", inv.Vendor)
+		fmt.Printf("Part Number: %s\n", inv.PartNumber)
+		fmt.Printf("Serial Number: %s\n", inv.SerialNumber)
+		fmt.Printf("Date Code: %s\n", inv.DateCode)
+		fmt.Printf("Connector: %s\n", inv.Connector)
+		fmt.Printf("Media Type: %s\n", inv.MediaType)
+		if inv.BitRate != 0 {
+			fmt.Printf("Bit Rate: %d Mb/s\n", inv.BitRate)
+		}
+		if inv.Wavelength != 0 {
+			fmt.Printf("Wavelength: %g nm\n", inv.Wavelength)
+		}
+	}
+
 	switch module.Type {
 	case sff.TypeSff8079:
 		sfp := module.Sff8079
-		fmt.Printf("Vendor: %s\n", sfp.Vendor)
-		fmt.Printf("Part Number: %s\n", sfp.VendorPn)
-		fmt.Printf("Serial Number: %s\n", sfp.VendorSn)
-		fmt.Printf("Date Code: %s\n", sfp.DateCode)
-		fmt.Printf("Connector: %s\n", sfp.Connector)
-		fmt.Printf("Bit Rate: %s\n", sfp.BrNominal)
+		if sfp.CalibrationMode != sff8079.CalibrationNone {
+			fmt.Printf("Status: %s\n", sfp.StatusControl)
+			fmt.Printf("Latched Alarms: %s\n", sfp.Alarms)
+			fmt.Printf("Latched Warnings: %s\n", sfp.Warnings)
+		}
 
 	case sff.TypeSff8636:
 		qsfp := module.Sff8636
-		fmt.Printf("Vendor: %s\n", qsfp.Vendor)
-		fmt.Printf("Part Number: %s\n", qsfp.VendorPn)
-		fmt.Printf("Serial Number: %s\n", qsfp.VendorSn)
-		fmt.Printf("Date Code: %s\n", qsfp.DateCode)
-		fmt.Printf("Connector: %s\n", qsfp.Connector)
-		fmt.Printf("Bit Rate: %s\n", qsfp.BrNominal)
+		fmt.Printf("Low Power Mode: %t\n", qsfp.ControlStatus.IsLowPowerMode())
+		fmt.Printf("Tx Disabled: %s\n", qsfp.TxDisable)
+		fmt.Printf("CDR Enabled: %s\n", qsfp.CdrControl)
 
-	default:
-		fmt.Printf("Unknown module type\n")
+	case sff.TypeCmis:
+		c := module.Cmis
+		fmt.Printf("Module State: %s\n", c.Lower.Status.State())
+		for _, a := range c.Applications {
+			fmt.Printf("Application %d: %s / %s\n", a.AppSel, a.HostInterface, a.MediaInterface)
+		}
+	}
+
+	if err := module.Validate(); err == nil {
+		fmt.Printf("Checksums: OK\n")
+	} else if *outputCol {
+		fmt.Printf("Checksums: \x1b[31m%v\x1b[0m\n", err)
+	} else {
+		fmt.Printf("Checksums: %v\n", err)
 	}
 }
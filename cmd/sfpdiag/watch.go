@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/bluecmd/go-sff"
+	"github.com/bluecmd/go-sff/common"
+)
+
+const clearScreen = "\x1b[H\x1b[2J"
+
+// monitor tracks one diagnostic value over the runtime of the watch
+type monitor struct {
+	name     string
+	unit     string
+	value    float64
+	delta    float64 // Change since the previous read
+	min, max float64
+	state    common.AlarmState
+	reads    int
+}
+
+func (m *monitor) update(v float64, state common.AlarmState) {
+	if m.reads == 0 {
+		m.min, m.max = v, v
+	} else {
+		m.delta = v - m.value
+	}
+	m.value = v
+	m.min = math.Min(m.min, v)
+	m.max = math.Max(m.max, v)
+	m.state = state
+	m.reads++
+}
+
+// watcher collects the diagnostic monitors of consecutive reads
+type watcher struct {
+	start    time.Time
+	monitors []*monitor
+	byName   map[string]*monitor
+}
+
+func newWatcher() *watcher {
+	return &watcher{start: time.Now(), byName: map[string]*monitor{}}
+}
+
+func (w *watcher) set(name, unit string, v float64, state common.AlarmState) {
+	m, ok := w.byName[name]
+	if !ok {
+		m = &monitor{name: name, unit: unit}
+		w.byName[name] = m
+		w.monitors = append(w.monitors, m)
+	}
+	m.update(v, state)
+}
+
+// update records the monitors of d. Power is tracked in dBm as that is what
+// link budgets are given in.
+func (w *watcher) update(d *common.Diagnostics) {
+	w.set("Temperature", "C", d.Temperature.Celsius(), d.TemperatureState)
+	w.set("Vcc", "V", d.Vcc.Volts(), d.VccState)
+	for _, l := range d.Lanes {
+		prefix := ""
+		if len(d.Lanes) > 1 {
+			prefix = fmt.Sprintf("Lane %d ", l.Lane)
+		}
+		w.set(prefix+"Tx Bias", "mA", l.TxBias.MilliAmp(), l.TxBiasState)
+		w.set(prefix+"Tx Power", "dBm", l.TxPower.DBm(), l.TxPowerState)
+		w.set(prefix+"Rx Power", "dBm", l.RxPower.DBm(), l.RxPowerState)
+	}
+}
+
+// stateColor returns the color of a value in the given alarm state
+func stateColor(s common.AlarmState) string {
+	switch {
+	case s.IsAlarm():
+		return common.ColorRed
+	case s.IsWarning():
+		return common.ColorYellow
+	}
+	return common.ColorGreen
+}
+
+// formatValue formats v, which is -Inf for a power of 0 mW
+func formatValue(v float64) string {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return "-inf"
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+func formatDelta(m *monitor) string {
+	if m.reads < 2 || math.IsInf(m.delta, 0) || math.IsNaN(m.delta) {
+		return "-"
+	}
+	return fmt.Sprintf("%+.2f", m.delta)
+}
+
+// String renders the monitors as a table, optionally colored like StringCol
+func (w *watcher) String(color bool) string {
+	col := func(c, s string) string {
+		if !color {
+			return s
+		}
+		return c + s + common.ColorClear
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-20s %10s %4s %9s %10s %10s  %s\n", "Monitor", "Value", "", "Delta", "Min", "Max", "State")
+	for _, m := range w.monitors {
+		fmt.Fprintf(&b, "%s %s %-4s %9s %10s %10s  %s\n",
+			col(common.ColorCyan, fmt.Sprintf("%-20s", m.name)),
+			col(stateColor(m.state), fmt.Sprintf("%10s", formatValue(m.value))),
+			m.unit,
+			formatDelta(m),
+			formatValue(m.min),
+			formatValue(m.max),
+			col(stateColor(m.state), m.state.String()))
+	}
+	return b.String()
+}
+
+// watch re-reads the diagnostic monitors of module at interval and redraws
+// the table until ctx is done, e.g. on SIGINT
+func watch(ctx context.Context, module *sff.Module, reader sff.Reader, interval time.Duration, color bool) error {
+	paged, ok := reader.(sff.PagedReader)
+	if !ok {
+		return fmt.Errorf("reader does not support reading regions")
+	}
+	if module.Diagnostics() == nil {
+		return fmt.Errorf("%s module does not provide diagnostics", module.Type)
+	}
+
+	inv := module.Inventory()
+	w := newWatcher()
+	var readErr error
+	for {
+		if readErr == nil {
+			w.update(module.Diagnostics())
+		}
+
+		fmt.Print(clearScreen)
+		fmt.Printf("%s %s %s, every %s, watching for %s\n\n", inv.Vendor, inv.PartNumber, inv.SerialNumber,
+			interval, time.Since(w.start).Round(time.Second))
+		fmt.Print(w.String(color))
+		if readErr != nil {
+			fmt.Printf("\nFailed to read monitors: %v\n", readErr)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+		readErr = module.Refresh(paged)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bluecmd/go-sff"
+	"github.com/bluecmd/go-sff/common"
+)
+
+func TestWatcher(t *testing.T) {
+	m, err := sff.Read(sff.NewFileReader("../../testdata/TR-FC85S-N00.bin"))
+	if err != nil {
+		t.Fatalf("Failed to read module: %v", err)
+	}
+	d := m.Diagnostics()
+	w := newWatcher()
+	w.update(d)
+
+	// Second read with lane 1 Rx power dropped to 0.5 mW and in alarm
+	d.Lanes[0].RxPower = common.PowerMilliWattBE{0x13, 0x88}
+	d.Lanes[0].RxPowerState = common.AlarmStateLowAlarm
+	w.update(d)
+
+	if len(w.monitors) != 2+4*3 {
+		t.Fatalf("Got %d monitors, want %d", len(w.monitors), 2+4*3)
+	}
+	rx := w.byName["Lane 1 Rx Power"]
+	if rx.reads != 2 || rx.min != rx.value || rx.max <= rx.min || rx.delta >= 0 {
+		t.Errorf("Unexpected Rx power stats %+v", rx)
+	}
+
+	out := w.String(false)
+	for _, want := range []string{
+		"Lane 1 Rx Power           -3.01 dBm      -2.03      -3.01      -0.98  Low alarm\n",
+		"Lane 2 Rx Power           -0.82 dBm      +0.00      -0.82      -0.82  Normal\n",
+		"Lane 4 Tx Bias",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\x1b") {
+		t.Error("Uncolored output contains escape sequences")
+	}
+	if out := w.String(true); !strings.Contains(out, common.ColorRed+"Low alarm"+common.ColorClear) {
+		t.Errorf("Alarm not colored red:\n%s", out)
+	}
+}
+
+func TestWatchStops(t *testing.T) {
+	r := sff.NewFileReader("../../testdata/TR-FC85S-N00.bin")
+	m, err := sff.Read(r)
+	if err != nil {
+		t.Fatalf("Failed to read module: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := watch(ctx, m, r, time.Hour, false); err != nil {
+		t.Errorf("watch() = %v after interrupt, want nil", err)
+	}
+}
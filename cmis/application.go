@@ -0,0 +1,78 @@
+package cmis
+
+import (
+	"fmt"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+// ApplicationDescriptor is an advertised application (lower page bytes
+// 86-117 for applications 1-8, page 01h bytes 223-250 for applications 9-15)
+type ApplicationDescriptor struct {
+	HostInterface      common.HostInterfaceID // Host electrical interface code
+	MediaInterface     byte                   // Media interface code, see common.MediaInterfaceName
+	LaneCounts         byte                   // Host lane count (bits 7-4) and media lane count (bits 3-0)
+	HostLaneAssignment LaneMask               // Allowed first host lanes of the application
+}
+
+// HostLaneCount returns the number of host lanes used by the application
+func (a ApplicationDescriptor) HostLaneCount() int {
+	return int(a.LaneCounts >> 4)
+}
+
+// MediaLaneCount returns the number of media lanes used by the application
+func (a ApplicationDescriptor) MediaLaneCount() int {
+	return int(a.LaneCounts & 0x0f)
+}
+
+// isEnd returns true for descriptors terminating the application list
+func (a ApplicationDescriptor) isEnd() bool {
+	return a.HostInterface == common.HostInterfaceEndOfList || a.HostInterface == common.HostInterfaceUndefined
+}
+
+// Application is a decoded application descriptor, identified by its AppSel
+// code as used in the data path configuration
+type Application struct {
+	AppSel              int      `json:"appSel"`
+	HostInterface       string   `json:"hostInterface"`
+	MediaInterface      string   `json:"mediaInterface"`
+	HostLaneCount       int      `json:"hostLaneCount"`
+	MediaLaneCount      int      `json:"mediaLaneCount"`
+	HostLaneAssignment  LaneMask `json:"hostLaneAssignment"`
+	MediaLaneAssignment LaneMask `json:"mediaLaneAssignment"`
+}
+
+func (a Application) String() string {
+	return fmt.Sprintf("%s / %s, %d host lanes, %d media lanes, host lane assignment %s, media lane assignment %s",
+		a.HostInterface, a.MediaInterface, a.HostLaneCount, a.MediaLaneCount, a.HostLaneAssignment, a.MediaLaneAssignment)
+}
+
+// applications returns the advertised applications, up to the first
+// undefined or end of list descriptor. Applications 9-15 and the media lane
+// assignments are only known when page 01h is present.
+func (c *Cmis) applications() []Application {
+	descs := c.Lower.Applications[:]
+	if c.Page01 != nil {
+		descs = append(descs, c.Page01.Applications[:]...)
+	}
+
+	var apps []Application
+	for i, d := range descs {
+		if d.isEnd() {
+			break
+		}
+		a := Application{
+			AppSel:             i + 1,
+			HostInterface:      d.HostInterface.String(),
+			MediaInterface:     common.MediaInterfaceName(c.Lower.MediaType, d.MediaInterface),
+			HostLaneCount:      d.HostLaneCount(),
+			MediaLaneCount:     d.MediaLaneCount(),
+			HostLaneAssignment: d.HostLaneAssignment,
+		}
+		if c.Page01 != nil {
+			a.MediaLaneAssignment = c.Page01.MediaLaneAssignment[i]
+		}
+		apps = append(apps, a)
+	}
+	return apps
+}
@@ -0,0 +1,91 @@
+package cmis
+
+import "github.com/bluecmd/go-sff/common"
+
+// flatPages lists the upper pages following upper page 00h in the flat layout
+// of common.MemoryFromFlatCmis
+var flatPages = []uint8{0x01, 0x02, 0x03, 0x10, 0x11}
+
+// flatImage returns the flat dump of m up to the last upper page present
+func flatImage(m *common.Memory) []byte {
+	n := len(flatPages)
+	for n > 0 && !m.Has(common.UpperPage(common.AddressA0, 0, flatPages[n-1])) {
+		n--
+	}
+	return m.FlatCmis()[:(2+n)*common.PageLen]
+}
+
+// flatOffset returns the offset of upper page p in the flat layout
+func flatOffset(p uint8) int {
+	for i, f := range flatPages {
+		if f == p {
+			return (2 + i) * common.PageLen
+		}
+	}
+	return common.PageLen
+}
+
+// flatPage is a mapped upper page and its checksum, if any
+type flatPage struct {
+	page  uint8
+	bytes []byte
+	code  *common.CheckCode
+}
+
+// Encode returns the flat dump of c as laid out by common.MemoryFromFlatCmis.
+// Bytes not mapped by Cmis, such as page 03h, are taken from the dump c was
+// decoded from, so decoding and encoding reproduces the dump exactly. The
+// dump ends after the last page present.
+//
+// With updateChecksums the checksums of pages 00h, 01h and 02h are
+// recomputed in the result, c is not modified.
+func (c *Cmis) Encode(updateChecksums bool) ([]byte, error) {
+	pages := []flatPage{{0x00, c.Page00.bytes(), &page00Checksum}}
+	if c.Page01 != nil {
+		pages = append(pages, flatPage{0x01, c.Page01.bytes(), &page01Checksum})
+	}
+	if c.Page02 != nil {
+		pages = append(pages, flatPage{0x02, c.Page02.bytes(), &page02Checksum})
+	}
+	if c.Page10 != nil {
+		pages = append(pages, flatPage{0x10, c.Page10.bytes(), nil})
+	}
+	if c.Page11 != nil {
+		pages = append(pages, flatPage{0x11, c.Page11.bytes(), nil})
+	}
+
+	n := len(c.image)
+	for _, p := range pages {
+		if end := flatOffset(p.page) + common.PageLen; end > n {
+			n = end
+		}
+	}
+	b := make([]byte, n)
+	copy(b, c.image)
+	copy(b, c.Lower.bytes())
+	for _, p := range pages {
+		o := flatOffset(p.page)
+		copy(b[o:o+common.PageLen], p.bytes)
+		if updateChecksums && p.code != nil {
+			w := upper(b[o : o+common.PageLen])
+			p.code.Update(w)
+			copy(b[o:], w[common.PageLen:])
+		}
+	}
+	return b, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, see Encode
+func (c *Cmis) MarshalBinary() ([]byte, error) {
+	return c.Encode(false)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, see Decode
+func (c *Cmis) UnmarshalBinary(b []byte) error {
+	d, err := Decode(b)
+	if err != nil {
+		return err
+	}
+	*c = *d
+	return nil
+}
@@ -0,0 +1,35 @@
+package cmis
+
+import "github.com/bluecmd/go-sff/common"
+
+// Inventory returns the vendor independent identification of the module.
+// CMIS modules advertise applications instead of a nominal bit rate, so
+// BitRate is 0. The wavelength and fiber lengths are only known when page 01h
+// is present.
+func (c *Cmis) Inventory() common.Inventory {
+	p := &c.Page00
+	inv := common.Inventory{
+		Identifier:   p.Identifier,
+		Vendor:       p.Vendor.String(),
+		OUI:          p.VendorOui,
+		PartNumber:   p.VendorPn.String(),
+		Revision:     p.VendorRev.String(),
+		SerialNumber: p.VendorSn.String(),
+		DateCode:     p.DateCode,
+		Connector:    p.Connector,
+		LaneCount:    len(p.MediaLaneInfo.Lanes().List()),
+		MediaType:    c.Lower.MediaType,
+		Reach:        common.Reach{Copper: p.CableLength.Meters()},
+	}
+	if l := c.Page01; l != nil {
+		inv.Reach.Smf = l.LinkLengths.Smf()
+		inv.Reach.Om5 = l.LinkLengths.Om5()
+		inv.Reach.Om4 = l.LinkLengths.Om4()
+		inv.Reach.Om3 = l.LinkLengths.Om3()
+		inv.Reach.Om2 = l.LinkLengths.Om2()
+		if !p.MediaTechnology.IsCopper() {
+			inv.Wavelength = l.Wavelength.Nanometers()
+		}
+	}
+	return inv
+}
@@ -0,0 +1,394 @@
+package cmis
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+// Lanes is the number of lanes per bank
+const Lanes = 8
+
+// LaneMask has bit n set for lane n+1
+type LaneMask byte
+
+// Has returns true if lane (1-8) is set
+func (m LaneMask) Has(lane int) bool {
+	return lane >= 1 && lane <= Lanes && m&(1<<(lane-1)) != 0
+}
+
+// List returns the set lanes
+func (m LaneMask) List() []int {
+	l := []int{}
+	for lane := 1; lane <= Lanes; lane++ {
+		if m.Has(lane) {
+			l = append(l, lane)
+		}
+	}
+	return l
+}
+
+func (m LaneMask) String() string {
+	l := m.List()
+	if len(l) == 0 {
+		return "None"
+	}
+	s := make([]string, len(l))
+	for i, lane := range l {
+		s[i] = fmt.Sprint(lane)
+	}
+	return strings.Join(s, ",")
+}
+
+func (m LaneMask) MarshalJSON() ([]byte, error) {
+	v := map[string]interface{}{
+		"value": m.List(),
+		"hex":   hex.EncodeToString([]byte{byte(m)}),
+	}
+	return json.Marshal(v)
+}
+
+func (m *LaneMask) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in)
+	if err != nil {
+		return err
+	}
+	*m = LaneMask(b)
+	return nil
+}
+
+// LaneFlags are the latched per lane high alarm, low alarm, high warning and
+// low warning flags of a monitor
+type LaneFlags struct {
+	HighAlarm   LaneMask `json:"highAlarm"`
+	LowAlarm    LaneMask `json:"lowAlarm"`
+	HighWarning LaneMask `json:"highWarning"`
+	LowWarning  LaneMask `json:"lowWarning"`
+}
+
+// State returns the most severe latched flag of lane (1-8)
+func (f LaneFlags) State(lane int) common.AlarmState {
+	return flagState(byte(f.HighAlarm), byte(f.LowAlarm), byte(f.HighWarning), byte(f.LowWarning), lane-1)
+}
+
+// DataPathState is the data path state machine state of a host lane
+type DataPathState byte
+
+const (
+	DPDeactivated DataPathState = 1
+	DPInit        DataPathState = 2
+	DPDeinit      DataPathState = 3
+	DPActivated   DataPathState = 4
+	DPTxTurnOn    DataPathState = 5
+	DPTxTurnOff   DataPathState = 6
+	DPInitialized DataPathState = 7
+)
+
+var dataPathStateNames = map[DataPathState]string{
+	DPDeactivated: "DPDeactivated",
+	DPInit:        "DPInit",
+	DPDeinit:      "DPDeinit",
+	DPActivated:   "DPActivated",
+	DPTxTurnOn:    "DPTxTurnOn",
+	DPTxTurnOff:   "DPTxTurnOff",
+	DPInitialized: "DPInitialized",
+}
+
+func (s DataPathState) String() string {
+	n, ok := dataPathStateNames[s]
+	if !ok {
+		return fmt.Sprintf("Reserved (%d)", byte(s))
+	}
+	return n
+}
+
+func (s DataPathState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s *DataPathState) UnmarshalJSON(in []byte) error {
+	var n string
+	if err := json.Unmarshal(in, &n); err != nil {
+		return err
+	}
+	for k, v := range dataPathStateNames {
+		if v == n {
+			*s = k
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown data path state %q", n)
+}
+
+// DataPathStates holds the data path state of lanes 1-8, two lanes per byte
+// with the lower numbered lane in bits 3-0
+type DataPathStates [4]byte
+
+// State returns the data path state of host lane (1-8)
+func (d DataPathStates) State(lane int) DataPathState {
+	b := d[(lane-1)/2]
+	if lane%2 == 0 {
+		b >>= 4
+	}
+	return DataPathState(b & 0x0f)
+}
+
+func (d DataPathStates) MarshalJSON() ([]byte, error) {
+	l := make([]string, Lanes)
+	for i := range l {
+		l[i] = d.State(i + 1).String()
+	}
+	m := map[string]interface{}{
+		"value": l,
+		"hex":   hex.EncodeToString(d[:]),
+	}
+	return json.Marshal(m)
+}
+
+func (d *DataPathStates) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < 4 {
+		return fmt.Errorf("length is shorter then DataPathStates type")
+	}
+
+	copy(d[:], b)
+	return nil
+}
+
+// DataPathConfig is the data path configuration of a host lane
+type DataPathConfig byte
+
+// AppSel returns the selected application (bits 7-4), 0 if the lane is unused
+func (d DataPathConfig) AppSel() int {
+	return int(d >> 4)
+}
+
+// DataPathID returns the first lane of the data path minus one (bits 3-1)
+func (d DataPathConfig) DataPathID() int {
+	return int(d>>1) & 0x07
+}
+
+// IsExplicitControl returns true if the signal integrity settings are
+// explicitly controlled by the host (bit 0)
+func (d DataPathConfig) IsExplicitControl() bool {
+	return d&0x01 != 0
+}
+
+func (d DataPathConfig) String() string {
+	if d.AppSel() == 0 {
+		return "Unused"
+	}
+	s := fmt.Sprintf("AppSel %d, Data Path %d", d.AppSel(), d.DataPathID())
+	if d.IsExplicitControl() {
+		s += ", Explicit control"
+	}
+	return s
+}
+
+func (d DataPathConfig) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"appSel":          d.AppSel(),
+		"dataPathId":      d.DataPathID(),
+		"explicitControl": d.IsExplicitControl(),
+		"hex":             hex.EncodeToString([]byte{byte(d)}),
+	}
+	return json.Marshal(m)
+}
+
+func (d *DataPathConfig) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in)
+	if err != nil {
+		return err
+	}
+	*d = DataPathConfig(b)
+	return nil
+}
+
+// Page10 holds the lane controls of upper page 10h, bank 0 (bytes 128-255)
+type Page10 struct {
+	DataPathDeinit        LaneMask              `json:"dataPathDeinit"`        // 128 - Data path deinit
+	InputPolarityFlipTx   LaneMask              `json:"inputPolarityFlipTx"`   // 129 - Tx input polarity flip
+	OutputDisableTx       LaneMask              `json:"outputDisableTx"`       // 130 - Tx output disable
+	AutoSquelchDisableTx  LaneMask              `json:"autoSquelchDisableTx"`  // 131 - Tx auto squelch disable
+	OutputSquelchForceTx  LaneMask              `json:"outputSquelchForceTx"`  // 132 - Tx output squelch force
+	_                     byte                  `json:"-"`                     // 133 - Reserved
+	AdaptiveInputEqFreeze LaneMask              `json:"adaptiveInputEqFreeze"` // 134 - Tx adaptive input EQ freeze
+	_                     [2]byte               `json:"-"`                     // 135-136 - Tx adaptive input EQ store
+	OutputPolarityFlipRx  LaneMask              `json:"outputPolarityFlipRx"`  // 137 - Rx output polarity flip
+	OutputDisableRx       LaneMask              `json:"outputDisableRx"`       // 138 - Rx output disable
+	AutoSquelchDisableRx  LaneMask              `json:"autoSquelchDisableRx"`  // 139 - Rx auto squelch disable
+	_                     [3]byte               `json:"-"`                     // 140-142 - Reserved
+	ApplyDataPathInit     LaneMask              `json:"-"`                     // 143 - Apply staged control set 0 with data path init
+	ApplyImmediate        LaneMask              `json:"-"`                     // 144 - Apply staged control set 0 immediately
+	DataPathConfig        [Lanes]DataPathConfig `json:"dataPathConfig"`        // 145-152 - Staged control set 0 data path configuration
+	_                     [103]byte             `json:"-"`                     // 153-255 - Staged control set signal integrity and set 1
+}
+
+// bytes relies on Page10 being exactly one page. Fails to compile otherwise.
+var _ [common.PageLen]byte = [unsafe.Sizeof(Page10{})]byte{}
+
+func (p *Page10) bytes() []byte {
+	return (*[common.PageLen]byte)(unsafe.Pointer(p))[:]
+}
+
+// Page11 holds the lane status, flags and monitors of upper page 11h, bank 0
+// (bytes 128-255)
+type Page11 struct {
+	DataPathStates       DataPathStates                  `json:"dataPathStates"`       // 128-131 - Data path state of lanes 1-8
+	OutputStatusRx       LaneMask                        `json:"outputStatusRx"`       // 132 - Rx output valid
+	OutputStatusTx       LaneMask                        `json:"outputStatusTx"`       // 133 - Tx output valid
+	DataPathStateChanged LaneMask                        `json:"dataPathStateChanged"` // 134 - Latched data path state changed
+	TxFault              LaneMask                        `json:"txFault"`              // 135 - Latched Tx fault
+	TxLos                LaneMask                        `json:"txLos"`                // 136 - Latched Tx LOS
+	TxCdrLol             LaneMask                        `json:"txCdrLol"`             // 137 - Latched Tx CDR LOL
+	TxAdaptiveEqFail     LaneMask                        `json:"txAdaptiveEqFail"`     // 138 - Latched Tx adaptive input EQ fault
+	TxPowerFlags         LaneFlags                       `json:"txPowerFlags"`         // 139-142 - Latched Tx power flags
+	TxBiasFlags          LaneFlags                       `json:"txBiasFlags"`          // 143-146 - Latched Tx bias flags
+	RxLos                LaneMask                        `json:"rxLos"`                // 147 - Latched Rx LOS
+	RxCdrLol             LaneMask                        `json:"rxCdrLol"`             // 148 - Latched Rx CDR LOL
+	RxPowerFlags         LaneFlags                       `json:"rxPowerFlags"`         // 149-152 - Latched Rx power flags
+	_                    byte                            `json:"-"`                    // 153 - Reserved
+	TxPower              [Lanes]common.PowerMilliWattBE  `json:"txPower"`              // 154-169 - Tx power of lanes 1-8
+	TxBias               [Lanes]common.CurrentMilliAmpBE `json:"txBias"`               // 170-185 - Tx bias of lanes 1-8, see MonitorSupport.TxBiasMultiplier
+	RxPower              [Lanes]common.PowerMilliWattBE  `json:"rxPower"`              // 186-201 - Rx power of lanes 1-8
+	_                    [4]byte                         `json:"-"`                    // 202-205 - Configuration command status
+	DataPathConfig       [Lanes]DataPathConfig           `json:"dataPathConfig"`       // 206-213 - Active data path configuration
+	_                    [42]byte                        `json:"-"`                    // 214-255 - Active signal integrity controls
+}
+
+// bytes relies on Page11 being exactly one page. Fails to compile otherwise.
+var _ [common.PageLen]byte = [unsafe.Sizeof(Page11{})]byte{}
+
+func (p *Page11) bytes() []byte {
+	return (*[common.PageLen]byte)(unsafe.Pointer(p))[:]
+}
+
+// Lane is the status and monitors of a media lane, merged from pages 10h and 11h
+type Lane struct {
+	Lane           int                     `json:"lane"`
+	TxDisabled     bool                    `json:"txDisabled"`
+	TxPower        common.PowerMilliWattBE `json:"txPower"`
+	TxBiasMilliAmp float64                 `json:"txBiasMilliAmp"` // Scaled by MonitorSupport.TxBiasMultiplier
+	RxPower        common.PowerMilliWattBE `json:"rxPower"`
+	TxPowerState   common.AlarmState       `json:"txPowerState"`
+	TxBiasState    common.AlarmState       `json:"txBiasState"`
+	RxPowerState   common.AlarmState       `json:"rxPowerState"`
+	TxFault        bool                    `json:"txFault"`
+	TxLos          bool                    `json:"txLos"`
+	TxCdrLol       bool                    `json:"txCdrLol"`
+	RxLos          bool                    `json:"rxLos"`
+	RxCdrLol       bool                    `json:"rxCdrLol"`
+}
+
+// Flags returns the latched fault, LOS, LOL and monitor flags of the lane
+func (l Lane) Flags() []string {
+	var f []string
+	if l.TxFault {
+		f = append(f, "Tx fault")
+	}
+	if l.TxLos {
+		f = append(f, "Tx LOS")
+	}
+	if l.TxCdrLol {
+		f = append(f, "Tx CDR LOL")
+	}
+	if l.RxLos {
+		f = append(f, "Rx LOS")
+	}
+	if l.RxCdrLol {
+		f = append(f, "Rx CDR LOL")
+	}
+	if l.TxPowerState != common.AlarmStateNormal {
+		f = append(f, "Tx power "+l.TxPowerState.String())
+	}
+	if l.TxBiasState != common.AlarmStateNormal {
+		f = append(f, "Tx bias "+l.TxBiasState.String())
+	}
+	if l.RxPowerState != common.AlarmStateNormal {
+		f = append(f, "Rx power "+l.RxPowerState.String())
+	}
+	return f
+}
+
+// HostLane is the data path state and active configuration of a host lane
+type HostLane struct {
+	Lane           int            `json:"lane"`
+	DataPathState  DataPathState  `json:"dataPathState"`
+	DataPathConfig DataPathConfig `json:"dataPathConfig"`
+}
+
+// txBiasMultiplier returns the Tx bias scaling advertised in page 01h
+func (c *Cmis) txBiasMultiplier() int {
+	if c.Page01 == nil {
+		return 1
+	}
+	return c.Page01.MonitorSupport.TxBiasMultiplier()
+}
+
+// txBias formats a Tx bias monitor or threshold value
+func (c *Cmis) txBias(v common.CurrentMilliAmpBE) string {
+	return fmt.Sprintf("%.3f mA", v.MilliAmp()*float64(c.txBiasMultiplier()))
+}
+
+// lanes returns the supported media lanes of bank 0, see Page00.MediaLaneInfo.
+// Lanes are only known when page 11h is present.
+func (c *Cmis) lanes() []Lane {
+	if c.Page11 == nil {
+		return nil
+	}
+	p := c.Page11
+	var lanes []Lane
+	for _, n := range c.Page00.MediaLaneInfo.Lanes().List() {
+		i := n - 1
+		l := Lane{
+			Lane:           n,
+			TxPower:        p.TxPower[i],
+			TxBiasMilliAmp: p.TxBias[i].MilliAmp() * float64(c.txBiasMultiplier()),
+			RxPower:        p.RxPower[i],
+			TxPowerState:   p.TxPowerFlags.State(n),
+			TxBiasState:    p.TxBiasFlags.State(n),
+			RxPowerState:   p.RxPowerFlags.State(n),
+			TxFault:        p.TxFault.Has(n),
+			TxLos:          p.TxLos.Has(n),
+			TxCdrLol:       p.TxCdrLol.Has(n),
+			RxLos:          p.RxLos.Has(n),
+			RxCdrLol:       p.RxCdrLol.Has(n),
+		}
+		if c.Page10 != nil {
+			l.TxDisabled = c.Page10.OutputDisableTx.Has(n)
+		}
+		lanes = append(lanes, l)
+	}
+	return lanes
+}
+
+// hostLanes returns the host lanes of bank 0 that are assigned to a data path.
+// Host lanes are only known when page 11h is present.
+func (c *Cmis) hostLanes() []HostLane {
+	if c.Page11 == nil {
+		return nil
+	}
+	var lanes []HostLane
+	for n := 1; n <= Lanes; n++ {
+		cfg := c.Page11.DataPathConfig[n-1]
+		if cfg.AppSel() == 0 {
+			continue
+		}
+		lanes = append(lanes, HostLane{Lane: n, DataPathState: c.Page11.DataPathStates.State(n), DataPathConfig: cfg})
+	}
+	return lanes
+}
@@ -0,0 +1,378 @@
+package cmis
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+// Lower is the CMIS lower page (bytes 0-127)
+type Lower struct {
+	Identifier      common.Identifier        `json:"identifier"`      // 0 - SFF-8024 Identifier
+	Revision        Revision                 `json:"revision"`        // 1 - CMIS revision
+	Characteristics Characteristics          `json:"characteristics"` // 2 - Memory model and management characteristics
+	Status          ModuleStatus             `json:"status"`          // 3 - Module state and interrupt
+	_               [4]byte                  `json:"-"`               // 4-7 - Lane flags summary
+	Flags           ModuleFlags              `json:"flags"`           // 8-11 - Module flags
+	_               [2]byte                  `json:"-"`               // 12-13 - Custom
+	Temperature     common.TemperatureQ8_8BE `json:"temperature"`     // 14-15 - Module temperature monitor
+	Vcc             common.VoltageVoltBE     `json:"vcc"`             // 16-17 - Supply voltage monitor
+	_               [8]byte                  `json:"-"`               // 18-25 - Aux monitors and custom
+	GlobalControls  GlobalControls           `json:"globalControls"`  // 26 - Module global controls
+	_               [58]byte                 `json:"-"`               // 27-84 - Masks, CDB and module-level controls
+	MediaType       common.MediaType         `json:"mediaType"`       // 85 - Media type of the media interface codes
+	Applications    [8]ApplicationDescriptor `json:"-"`               // 86-117 - Application descriptors 1-8
+	_               [8]byte                  `json:"-"`               // 118-125 - Password entry and change
+	BankSelect      byte                     `json:"-"`               // 126 - Bank select
+	PageSelect      byte                     `json:"-"`               // 127 - Page select
+}
+
+// bytes relies on Lower being exactly one page. Fails to compile otherwise.
+var _ [common.PageLen]byte = [unsafe.Sizeof(Lower{})]byte{}
+
+func (l *Lower) bytes() []byte {
+	return (*[common.PageLen]byte)(unsafe.Pointer(l))[:]
+}
+
+// Revision is the CMIS revision (byte 1), major in bits 7-4 and minor in bits 3-0
+type Revision byte
+
+func (r Revision) Major() int {
+	return int(r >> 4)
+}
+
+func (r Revision) Minor() int {
+	return int(r & 0x0f)
+}
+
+func (r Revision) String() string {
+	return fmt.Sprintf("%d.%d", r.Major(), r.Minor())
+}
+
+func (r Revision) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": r.String(),
+		"hex":   hex.EncodeToString([]byte{byte(r)}),
+	}
+	return json.Marshal(m)
+}
+
+func (r *Revision) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in)
+	if err != nil {
+		return err
+	}
+	*r = Revision(b)
+	return nil
+}
+
+// Characteristics is the management characteristics byte (byte 2)
+type Characteristics byte
+
+// IsFlatMemory returns true if the module only implements the lower page and
+// upper page 00h (bit 7)
+func (c Characteristics) IsFlatMemory() bool {
+	return c&0x80 != 0
+}
+
+// IsSteppedConfigOnly returns true if the module only supports stepped data
+// path configuration (bit 6)
+func (c Characteristics) IsSteppedConfigOnly() bool {
+	return c&0x40 != 0
+}
+
+func (c Characteristics) List() []string {
+	var l []string
+	if c.IsFlatMemory() {
+		l = append(l, "Flat memory")
+	} else {
+		l = append(l, "Paged memory")
+	}
+	if c.IsSteppedConfigOnly() {
+		l = append(l, "Stepped configuration only")
+	}
+	return l
+}
+
+func (c Characteristics) String() string {
+	return strings.Join(c.List(), ", ")
+}
+
+func (c Characteristics) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": c.List(),
+		"hex":   hex.EncodeToString([]byte{byte(c)}),
+	}
+	return json.Marshal(m)
+}
+
+func (c *Characteristics) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in)
+	if err != nil {
+		return err
+	}
+	*c = Characteristics(b)
+	return nil
+}
+
+// ModuleState is the module state machine state (byte 3 bits 3-1)
+type ModuleState byte
+
+const (
+	ModuleLowPwr ModuleState = 1
+	ModulePwrUp  ModuleState = 2
+	ModuleReady  ModuleState = 3
+	ModulePwrDn  ModuleState = 4
+	ModuleFault  ModuleState = 5
+)
+
+var moduleStateNames = map[ModuleState]string{
+	ModuleLowPwr: "ModuleLowPwr",
+	ModulePwrUp:  "ModulePwrUp",
+	ModuleReady:  "ModuleReady",
+	ModulePwrDn:  "ModulePwrDn",
+	ModuleFault:  "ModuleFault",
+}
+
+func (s ModuleState) String() string {
+	n, ok := moduleStateNames[s]
+	if !ok {
+		return fmt.Sprintf("Reserved (%d)", byte(s))
+	}
+	return n
+}
+
+// ModuleStatus is the module global status byte (byte 3)
+type ModuleStatus byte
+
+// State returns the module state (bits 3-1)
+func (s ModuleStatus) State() ModuleState {
+	return ModuleState(s>>1) & 0x07
+}
+
+// IsInterruptDeasserted returns true if the IntL signal is deasserted (bit 0)
+func (s ModuleStatus) IsInterruptDeasserted() bool {
+	return s&0x01 != 0
+}
+
+func (s ModuleStatus) String() string {
+	if s.IsInterruptDeasserted() {
+		return s.State().String()
+	}
+	return s.State().String() + ", Interrupt asserted"
+}
+
+func (s ModuleStatus) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"state":     s.State().String(),
+		"interrupt": !s.IsInterruptDeasserted(),
+		"hex":       hex.EncodeToString([]byte{byte(s)}),
+	}
+	return json.Marshal(m)
+}
+
+func (s *ModuleStatus) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in)
+	if err != nil {
+		return err
+	}
+	*s = ModuleStatus(b)
+	return nil
+}
+
+// ModuleFlags are the latched module flags (bytes 8-11)
+type ModuleFlags [4]byte
+
+// IsModuleStateChanged returns true if the module state changed (byte 8 bit 0)
+func (f ModuleFlags) IsModuleStateChanged() bool {
+	return f[0]&0x01 != 0
+}
+
+// IsModuleFirmwareFault returns true on a module firmware fault (byte 8 bit 1)
+func (f ModuleFlags) IsModuleFirmwareFault() bool {
+	return f[0]&0x02 != 0
+}
+
+// IsDataPathFirmwareFault returns true on a data path firmware fault (byte 8 bit 2)
+func (f ModuleFlags) IsDataPathFirmwareFault() bool {
+	return f[0]&0x04 != 0
+}
+
+// TemperatureState returns the latched temperature monitor state (byte 9 bits 3-0)
+func (f ModuleFlags) TemperatureState() common.AlarmState {
+	return flagState(f[1], f[1]>>1, f[1]>>2, f[1]>>3, 0)
+}
+
+// VccState returns the latched supply voltage monitor state (byte 9 bits 7-4)
+func (f ModuleFlags) VccState() common.AlarmState {
+	return flagState(f[1]>>4, f[1]>>5, f[1]>>6, f[1]>>7, 0)
+}
+
+// flagState returns the most severe of the latched flags at bit
+func flagState(highAlarm, lowAlarm, highWarning, lowWarning byte, bit int) common.AlarmState {
+	switch {
+	case highAlarm>>bit&1 != 0:
+		return common.AlarmStateHighAlarm
+	case lowAlarm>>bit&1 != 0:
+		return common.AlarmStateLowAlarm
+	case highWarning>>bit&1 != 0:
+		return common.AlarmStateHighWarning
+	case lowWarning>>bit&1 != 0:
+		return common.AlarmStateLowWarning
+	}
+	return common.AlarmStateNormal
+}
+
+func (f ModuleFlags) List() []string {
+	var l []string
+	if f.IsModuleStateChanged() {
+		l = append(l, "Module state changed")
+	}
+	if f.IsModuleFirmwareFault() {
+		l = append(l, "Module firmware fault")
+	}
+	if f.IsDataPathFirmwareFault() {
+		l = append(l, "Data path firmware fault")
+	}
+	if s := f.TemperatureState(); s != common.AlarmStateNormal {
+		l = append(l, "Temperature "+s.String())
+	}
+	if s := f.VccState(); s != common.AlarmStateNormal {
+		l = append(l, "Vcc "+s.String())
+	}
+	return l
+}
+
+func (f ModuleFlags) String() string {
+	l := f.List()
+	if len(l) == 0 {
+		return "None"
+	}
+	return strings.Join(l, ", ")
+}
+
+func (f ModuleFlags) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": f.List(),
+		"hex":   hex.EncodeToString(f[:]),
+	}
+	return json.Marshal(m)
+}
+
+func (f *ModuleFlags) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < 4 {
+		return fmt.Errorf("length is shorter then ModuleFlags type")
+	}
+
+	copy(f[:], b)
+	return nil
+}
+
+// GlobalControls is the module global controls byte (byte 26)
+type GlobalControls byte
+
+// IsBankBroadcastEnabled returns true if writes are broadcast to all banks (bit 7)
+func (g GlobalControls) IsBankBroadcastEnabled() bool {
+	return g&0x80 != 0
+}
+
+// IsLowPwrAllowRequestHW returns true if the LPMode signal is honoured (bit 6)
+func (g GlobalControls) IsLowPwrAllowRequestHW() bool {
+	return g&0x40 != 0
+}
+
+// IsSquelchMethodPav returns true if Tx squelch reduces average power instead
+// of OMA (bit 5)
+func (g GlobalControls) IsSquelchMethodPav() bool {
+	return g&0x20 != 0
+}
+
+// IsLowPwrRequestSW returns true if software requests low power mode (bit 4)
+func (g GlobalControls) IsLowPwrRequestSW() bool {
+	return g&0x10 != 0
+}
+
+// IsSoftwareReset returns true if a software reset is requested (bit 3)
+func (g GlobalControls) IsSoftwareReset() bool {
+	return g&0x08 != 0
+}
+
+func (g GlobalControls) List() []string {
+	var l []string
+	if g.IsBankBroadcastEnabled() {
+		l = append(l, "Bank broadcast enabled")
+	}
+	if g.IsLowPwrAllowRequestHW() {
+		l = append(l, "Low power allowed by LPMode")
+	}
+	if g.IsSquelchMethodPav() {
+		l = append(l, "Squelch reduces Pav")
+	}
+	if g.IsLowPwrRequestSW() {
+		l = append(l, "Low power requested by software")
+	}
+	if g.IsSoftwareReset() {
+		l = append(l, "Software reset")
+	}
+	return l
+}
+
+func (g GlobalControls) String() string {
+	l := g.List()
+	if len(l) == 0 {
+		return "None"
+	}
+	return strings.Join(l, ", ")
+}
+
+func (g GlobalControls) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": g.List(),
+		"hex":   hex.EncodeToString([]byte{byte(g)}),
+	}
+	return json.Marshal(m)
+}
+
+func (g *GlobalControls) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in)
+	if err != nil {
+		return err
+	}
+	*g = GlobalControls(b)
+	return nil
+}
+
+// hexFromJSON returns the single byte "hex" value of a JSON object
+func hexFromJSON(in []byte) (byte, error) {
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(in, &m); err != nil {
+		return 0, err
+	}
+	s, ok := m["hex"].(string)
+	if !ok {
+		return 0, fmt.Errorf("missing hex value")
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return 0, err
+	}
+	if len(b) < 1 {
+		return 0, fmt.Errorf("empty hex value")
+	}
+	return b[0], nil
+}
@@ -0,0 +1,332 @@
+package cmis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+const (
+	red     = common.ColorRed
+	green   = common.ColorGreen
+	yellow  = common.ColorYellow
+	blue    = common.ColorBlue
+	magenta = common.ColorMagenta
+	cyan    = common.ColorCyan
+	white   = common.ColorWhite
+	clear   = common.ColorClear
+)
+
+// Cmis is a CMIS 4.x/5.x module (QSFP-DD, OSFP, QSFP112 and other CMIS form
+// factors). Optional pages are nil if the module does not implement them or
+// they were not read. Banked pages only cover bank 0, lanes 1-8.
+type Cmis struct {
+	Lower  Lower   `json:"lower"`            // Lower page
+	Page00 Page00  `json:"page00"`           // Administrative information
+	Page01 *Page01 `json:"page01,omitempty"` // Advertising
+	Page02 *Page02 `json:"page02,omitempty"` // Thresholds
+	Page10 *Page10 `json:"page10,omitempty"` // Lane controls
+	Page11 *Page11 `json:"page11,omitempty"` // Lane status and monitors
+
+	// Decoded from the pages above
+	Applications []Application `json:"applications"`        // Advertised applications
+	HostLanes    []HostLane    `json:"hostLanes,omitempty"` // Host lanes assigned to a data path
+	Lanes        []Lane        `json:"lanes,omitempty"`     // Supported media lanes
+
+	image []byte // Flat dump c was decoded from, see Encode
+}
+
+// IsCmis returns true for SFF-8024 identifiers of modules managed with CMIS
+func IsCmis(id byte) bool {
+	switch id {
+	case common.IdentifierQsfpDd, common.IdentifierOsfp, common.IdentifierDsfp,
+		common.IdentifierQsfpCmis, common.IdentifierSfpDdCmis, common.IdentifierSfpCmis,
+		common.IdentifierOsfpXd:
+		return true
+	}
+	return false
+}
+
+// Decode decodes a flat CMIS dump as laid out by common.MemoryFromFlatCmis.
+// At least the lower page and upper page 00h (256 bytes) are required, and
+// the dump must not end within a page. eeprom is copied, so the caller may
+// reuse it.
+func Decode(eeprom []byte) (*Cmis, error) {
+	if len(eeprom) < 2*common.PageLen {
+		return nil, fmt.Errorf("eeprom size to small needs to be %d bytes or larger got: %d bytes", 2*common.PageLen, len(eeprom))
+	}
+	m, err := common.ParseFlatCmis(eeprom)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeMemory(m)
+}
+
+// DecodeMemory decodes the lower page, upper page 00h and the optional pages
+// 01h, 02h and bank 0 pages 10h and 11h from paged module memory
+func DecodeMemory(m *common.Memory) (*Cmis, error) {
+	lower, err := m.Read(common.LowerPage(common.AddressA0))
+	if err != nil {
+		return nil, err
+	}
+	if !IsCmis(lower[0]) {
+		return nil, fmt.Errorf("unknown eeprom standard, identifier: 0x%02x", lower[0])
+	}
+	page00, err := m.Read(common.UpperPage(common.AddressA0, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cmis{image: flatImage(m)}
+	copy(c.Lower.bytes(), lower)
+	copy(c.Page00.bytes(), page00)
+	if b, err := m.Read(common.UpperPage(common.AddressA0, 0, 0x01)); err == nil {
+		c.Page01 = &Page01{}
+		copy(c.Page01.bytes(), b)
+	}
+	if b, err := m.Read(common.UpperPage(common.AddressA0, 0, 0x02)); err == nil {
+		c.Page02 = &Page02{}
+		copy(c.Page02.bytes(), b)
+	}
+	if b, err := m.Read(common.UpperPage(common.AddressA0, 0, 0x10)); err == nil {
+		c.Page10 = &Page10{}
+		copy(c.Page10.bytes(), b)
+	}
+	if b, err := m.Read(common.UpperPage(common.AddressA0, 0, 0x11)); err == nil {
+		c.Page11 = &Page11{}
+		copy(c.Page11.bytes(), b)
+	}
+
+	c.Applications = c.applications()
+	c.HostLanes = c.hostLanes()
+	c.Lanes = c.lanes()
+	return c, nil
+}
+
+var (
+	page00Checksum = common.CheckCode{Name: "Page 00h checksum", Start: 128, Offset: 222}
+	page01Checksum = common.CheckCode{Name: "Page 01h checksum", Start: 130, Offset: 255}
+	page02Checksum = common.CheckCode{Name: "Page 02h checksum", Start: 128, Offset: 255}
+)
+
+// upper returns a 256 byte buffer with the upper page p at offset 128, so
+// check codes can use the offsets of the specification
+func upper(p []byte) []byte {
+	return append(make([]byte, common.PageLen), p...)
+}
+
+type checkedPage struct {
+	code common.CheckCode
+	page []byte
+}
+
+// checkedPages returns the present pages carrying a checksum
+func (c *Cmis) checkedPages() []checkedPage {
+	p := []checkedPage{{page00Checksum, c.Page00.bytes()}}
+	if c.Page01 != nil {
+		p = append(p, checkedPage{page01Checksum, c.Page01.bytes()})
+	}
+	if c.Page02 != nil {
+		p = append(p, checkedPage{page02Checksum, c.Page02.bytes()})
+	}
+	return p
+}
+
+// Validate verifies the checksums of pages 00h, 01h and 02h. Mismatches are
+// reported as *common.ChecksumError, or common.ChecksumErrors if more than
+// one page checksum is wrong.
+func (c *Cmis) Validate() error {
+	var errs common.ChecksumErrors
+	for _, p := range c.checkedPages() {
+		if err := p.code.Verify(upper(p.page)); err != nil {
+			errs = append(errs, err.(*common.ChecksumError))
+		}
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return errs
+}
+
+// UpdateChecksums recomputes the checksums of pages 00h, 01h and 02h from
+// the current contents
+func (c *Cmis) UpdateChecksums() {
+	for _, p := range c.checkedPages() {
+		b := upper(p.page)
+		p.code.Update(b)
+		copy(p.page, b[common.PageLen:])
+	}
+}
+
+// field is a line of String and StringCol output
+type field struct {
+	key   string
+	value string
+	color string // Value color for StringCol, green if empty
+}
+
+// checksumField describes the checksum of page
+func checksumField(key string, code common.CheckCode, page []byte) field {
+	b := upper(page)
+	f := field{key: key, value: code.Status(b)}
+	if code.Verify(b) != nil {
+		f.color = red
+	}
+	return f
+}
+
+// alertColor returns red if any of l is set
+func alertColor(l []string) string {
+	if len(l) > 0 {
+		return red
+	}
+	return ""
+}
+
+func noneIfEmpty(l []string) string {
+	if len(l) == 0 {
+		return "None"
+	}
+	return strings.Join(l, ", ")
+}
+
+func (c *Cmis) fields() []field {
+	l := &c.Lower
+	state := field{key: "Module State [3]", value: l.Status.String()}
+	if l.Status.State() == ModuleFault {
+		state.color = red
+	}
+	f := []field{
+		{key: "Identifier [0]", value: fmt.Sprintf("0x%02x (%s)", byte(l.Identifier), l.Identifier)},
+		{key: "CMIS Revision [1]", value: l.Revision.String()},
+		{key: "Memory Model [2]", value: l.Characteristics.String()},
+		state,
+		{key: "Module Flags [8-11]", value: l.Flags.String(), color: alertColor(l.Flags.List())},
+		{key: "Temperature [14-15]", value: l.Temperature.String()},
+		{key: "Supply Voltage [16-17]", value: l.Vcc.String()},
+		{key: "Global Controls [26]", value: l.GlobalControls.String()},
+		{key: "Media Type [85]", value: fmt.Sprintf("0x%02x (%s)", byte(l.MediaType), l.MediaType)},
+	}
+	for _, a := range c.Applications {
+		key := fmt.Sprintf("Application %d [%d-%d]", a.AppSel, 86+4*(a.AppSel-1), 89+4*(a.AppSel-1))
+		if a.AppSel > len(l.Applications) {
+			key = fmt.Sprintf("Application %d [01h %d-%d]", a.AppSel, 223+4*(a.AppSel-9), 226+4*(a.AppSel-9))
+		}
+		f = append(f, field{key: key, value: a.String()})
+	}
+
+	p := &c.Page00
+	f = append(f,
+		field{key: "Identifier [128]", value: fmt.Sprintf("0x%02x (%s)", byte(p.Identifier), p.Identifier)},
+		field{key: "Vendor [129-144]", value: p.Vendor.String()},
+		field{key: "Vendor OUI [145-147]", value: p.VendorOui.String()},
+		field{key: "Vendor PN [148-163]", value: p.VendorPn.String()},
+		field{key: "Vendor Rev [164-165]", value: p.VendorRev.String()},
+		field{key: "Vendor SN [166-181]", value: p.VendorSn.String()},
+		field{key: "Date Code [182-189]", value: p.DateCode.String()},
+		field{key: "CLEI Code [190-199]", value: p.CleiCode.String()},
+		field{key: "Power Class [200]", value: p.PowerClass.String()},
+		field{key: "Max Power [201]", value: p.MaxPower.String()},
+		field{key: "Cable Length [202]", value: p.CableLength.String()},
+		field{key: "Connector [203]", value: fmt.Sprintf("0x%02x (%s)", byte(p.Connector), p.Connector)},
+		field{key: "Media Lanes [210]", value: p.MediaLaneInfo.String()},
+		field{key: "Media Technology [212]", value: fmt.Sprintf("0x%02x (%s)", byte(p.MediaTechnology), p.MediaTechnology)},
+		checksumField("Checksum [222]", page00Checksum, p.bytes()),
+	)
+
+	if p := c.Page01; p != nil {
+		f = append(f,
+			field{key: "Firmware Version [01h 128-129]", value: p.FirmwareVersion.String()},
+			field{key: "Hardware Revision [01h 130-131]", value: p.HardwareRevision.String()},
+			field{key: "Link Lengths [01h 132-136]", value: p.LinkLengths.String()},
+			field{key: "Wavelength [01h 138-139]", value: p.Wavelength.String()},
+			field{key: "  Tolerance [01h 140-141]", value: p.WavelengthTolerance.String()},
+			field{key: "Supported Banks [01h 142]", value: p.PagesSupported.String()},
+			field{key: "Monitors [01h 159-160]", value: p.MonitorSupport.String()},
+			checksumField("Checksum [01h 255]", page01Checksum, p.bytes()),
+		)
+	}
+
+	if p := c.Page02; p != nil {
+		f = append(f, c.thresholds()...)
+		f = append(f, checksumField("Checksum [02h 255]", page02Checksum, p.bytes()))
+	}
+
+	for _, h := range c.HostLanes {
+		f = append(f, field{
+			key:   fmt.Sprintf("Host Lane %d [11h %d, %d]", h.Lane, 128+(h.Lane-1)/2, 205+h.Lane),
+			value: fmt.Sprintf("%s, %s", h.DataPathState, h.DataPathConfig),
+		})
+	}
+
+	for _, ln := range c.Lanes {
+		i := 2 * (ln.Lane - 1)
+		tx := "Enabled"
+		if ln.TxDisabled {
+			tx = "Disabled"
+		}
+		f = append(f,
+			field{key: fmt.Sprintf("Lane %d Tx Output [10h 130]", ln.Lane), value: tx},
+			field{key: fmt.Sprintf("Lane %d Tx Power [11h %d-%d]", ln.Lane, 154+i, 155+i), value: ln.TxPower.String()},
+			field{key: fmt.Sprintf("Lane %d Tx Bias [11h %d-%d]", ln.Lane, 170+i, 171+i), value: fmt.Sprintf("%.3f mA", ln.TxBiasMilliAmp)},
+			field{key: fmt.Sprintf("Lane %d Rx Power [11h %d-%d]", ln.Lane, 186+i, 187+i), value: ln.RxPower.String()},
+			field{key: fmt.Sprintf("Lane %d Flags [11h 135-152]", ln.Lane), value: noneIfEmpty(ln.Flags()), color: alertColor(ln.Flags())},
+		)
+	}
+	return f
+}
+
+// thresholds returns the page 02h threshold lines
+func (c *Cmis) thresholds() []field {
+	p := c.Page02
+	return []field{
+		{key: "Temperature High Alarm [02h 128-129]", value: p.TempThresholds.HighAlarm.String()},
+		{key: "Temperature Low Alarm [02h 130-131]", value: p.TempThresholds.LowAlarm.String()},
+		{key: "Temperature High Warning [02h 132-133]", value: p.TempThresholds.HighWarning.String()},
+		{key: "Temperature Low Warning [02h 134-135]", value: p.TempThresholds.LowWarning.String()},
+		{key: "Vcc High Alarm [02h 136-137]", value: p.VccThresholds.HighAlarm.String()},
+		{key: "Vcc Low Alarm [02h 138-139]", value: p.VccThresholds.LowAlarm.String()},
+		{key: "Vcc High Warning [02h 140-141]", value: p.VccThresholds.HighWarning.String()},
+		{key: "Vcc Low Warning [02h 142-143]", value: p.VccThresholds.LowWarning.String()},
+		{key: "Tx Power High Alarm [02h 176-177]", value: p.TxPowerThresholds.HighAlarm.String()},
+		{key: "Tx Power Low Alarm [02h 178-179]", value: p.TxPowerThresholds.LowAlarm.String()},
+		{key: "Tx Power High Warning [02h 180-181]", value: p.TxPowerThresholds.HighWarning.String()},
+		{key: "Tx Power Low Warning [02h 182-183]", value: p.TxPowerThresholds.LowWarning.String()},
+		{key: "Tx Bias High Alarm [02h 184-185]", value: c.txBias(p.TxBiasThresholds.HighAlarm)},
+		{key: "Tx Bias Low Alarm [02h 186-187]", value: c.txBias(p.TxBiasThresholds.LowAlarm)},
+		{key: "Tx Bias High Warning [02h 188-189]", value: c.txBias(p.TxBiasThresholds.HighWarning)},
+		{key: "Tx Bias Low Warning [02h 190-191]", value: c.txBias(p.TxBiasThresholds.LowWarning)},
+		{key: "Rx Power High Alarm [02h 192-193]", value: p.RxPowerThresholds.HighAlarm.String()},
+		{key: "Rx Power Low Alarm [02h 194-195]", value: p.RxPowerThresholds.LowAlarm.String()},
+		{key: "Rx Power High Warning [02h 196-197]", value: p.RxPowerThresholds.HighWarning.String()},
+		{key: "Rx Power Low Warning [02h 198-199]", value: p.RxPowerThresholds.LowWarning.String()},
+	}
+}
+
+func (c *Cmis) String() string {
+	var result strings.Builder
+	for _, f := range c.fields() {
+		result.WriteString(fmt.Sprintf("%-50s : %s\n", f.key, f.value))
+	}
+	return result.String()
+}
+
+func strCol(k string, v string, c1 string, c2 string) string {
+	return fmt.Sprintf("%s%-50s%s : %s%s%s\n", c1, k, clear, c2, v, clear)
+}
+
+func (c *Cmis) StringCol() string {
+	var result strings.Builder
+	for _, f := range c.fields() {
+		color := f.color
+		if color == "" {
+			color = green
+		}
+		result.WriteString(strCol(f.key, f.value, cyan, color))
+	}
+	return result.String()
+}
@@ -0,0 +1,180 @@
+package cmis
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+func TestPageLayout(t *testing.T) {
+	sizes := map[string]uintptr{
+		"Lower":  unsafe.Sizeof(Lower{}),
+		"Page00": unsafe.Sizeof(Page00{}),
+		"Page01": unsafe.Sizeof(Page01{}),
+		"Page02": unsafe.Sizeof(Page02{}),
+		"Page10": unsafe.Sizeof(Page10{}),
+		"Page11": unsafe.Sizeof(Page11{}),
+	}
+	for name, size := range sizes {
+		if size != common.PageLen {
+			t.Errorf("%s is %d bytes, want %d", name, size, common.PageLen)
+		}
+	}
+
+	offsets := []struct {
+		name string
+		got  uintptr
+		want uintptr
+	}{
+		{"Lower.Temperature", unsafe.Offsetof(Lower{}.Temperature), 14},
+		{"Lower.MediaType", unsafe.Offsetof(Lower{}.MediaType), 85},
+		{"Lower.Applications", unsafe.Offsetof(Lower{}.Applications), 86},
+		{"Page00.PowerClass", unsafe.Offsetof(Page00{}.PowerClass), 200 - 128},
+		{"Page00.Checksum", unsafe.Offsetof(Page00{}.Checksum), 222 - 128},
+		{"Page01.MonitorSupport", unsafe.Offsetof(Page01{}.MonitorSupport), 159 - 128},
+		{"Page01.Applications", unsafe.Offsetof(Page01{}.Applications), 223 - 128},
+		{"Page02.TxPowerThresholds", unsafe.Offsetof(Page02{}.TxPowerThresholds), 176 - 128},
+		{"Page10.DataPathConfig", unsafe.Offsetof(Page10{}.DataPathConfig), 145 - 128},
+		{"Page11.TxPower", unsafe.Offsetof(Page11{}.TxPower), 154 - 128},
+		{"Page11.DataPathConfig", unsafe.Offsetof(Page11{}.DataPathConfig), 206 - 128},
+	}
+	for _, o := range offsets {
+		if o.got != o.want {
+			t.Errorf("%s at offset %d, want %d", o.name, o.got, o.want)
+		}
+	}
+}
+
+// testEeprom returns a flat 400GBASE-DR4 QSFP-DD image with pages 00h-03h,
+// 10h and 11h
+func testEeprom() []byte {
+	b := make([]byte, 896)
+	b[0] = common.IdentifierQsfpDd
+	b[1] = 0x50
+	b[3] = byte(ModuleReady)<<1 | 0x01
+	b[85] = common.MediaTypeSmf
+	copy(b[86:], []byte{0x11, 0x1C, 0x84, 0x01}) // 400GAUI-8 / 400GBASE-DR4
+	copy(b[90:], []byte{0x0D, 0x14, 0x21, 0x55}) // 100GAUI-2 / 100GBASE-DR
+	b[94] = 0xFF
+
+	b[128] = common.IdentifierQsfpDd
+	copy(b[129:145], "TEST VENDOR     ")
+	b[210] = 0xF0 // Media lanes 1-4
+
+	b[256+159-128] = 0x03
+	b[256+160-128] = 0x07 | 0x08 // Tx bias x2
+	b[256+176-128] = 0x01
+	b[256+177-128] = 0x0F
+
+	p11 := 768 - 128
+	b[p11+128] = byte(DPActivated) | byte(DPActivated)<<4
+	b[p11+136] = 0x02                   // Tx LOS lane 2
+	b[p11+152] = 0x08                   // Rx power low warning lane 4
+	b[p11+170], b[p11+171] = 0x27, 0x10 // 10000 * 2 µA * 2
+	b[p11+206], b[p11+207] = 0x10, 0x10
+
+	m := common.MemoryFromFlatCmis(b)
+	c, _ := DecodeMemory(m)
+	c.UpdateChecksums()
+	copy(b[128:256], c.Page00.bytes())
+	copy(b[256:384], c.Page01.bytes())
+	copy(b[384:512], c.Page02.bytes())
+	return b
+}
+
+func TestDecode(t *testing.T) {
+	c, err := Decode(testEeprom())
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+
+	if s := c.Lower.Status.State(); s != ModuleReady {
+		t.Errorf("State() = %v, want %v", s, ModuleReady)
+	}
+	if r := c.Lower.Revision.String(); r != "5.0" {
+		t.Errorf("Revision = %s, want 5.0", r)
+	}
+	if v := c.Page00.Vendor.String(); v != "TEST VENDOR" {
+		t.Errorf("Vendor = %q, want TEST VENDOR", v)
+	}
+
+	if len(c.Applications) != 2 {
+		t.Fatalf("Got %d applications, want 2", len(c.Applications))
+	}
+	a := c.Applications[1]
+	if a.AppSel != 2 || a.HostInterface != "100GAUI-2 C2M (Annex 135G)" || a.MediaInterface != "100GBASE-DR (Clause 140)" {
+		t.Errorf("Unexpected application %+v", a)
+	}
+	if a.HostLaneCount != 2 || a.MediaLaneCount != 1 || a.HostLaneAssignment.String() != "1,3,5,7" || a.MediaLaneAssignment.String() != "1,2,3,4" {
+		t.Errorf("Unexpected lane assignment %+v", a)
+	}
+
+	if len(c.HostLanes) != 2 || c.HostLanes[1].DataPathState != DPActivated || c.HostLanes[1].DataPathConfig.AppSel() != 1 {
+		t.Errorf("Unexpected host lanes %+v", c.HostLanes)
+	}
+
+	if len(c.Lanes) != 4 {
+		t.Fatalf("Got %d lanes, want 4", len(c.Lanes))
+	}
+	if c.Lanes[0].TxBiasMilliAmp != 40 {
+		t.Errorf("Lane 1 Tx bias = %f mA, want 40 mA", c.Lanes[0].TxBiasMilliAmp)
+	}
+	if !c.Lanes[1].TxLos || c.Lanes[0].TxLos {
+		t.Errorf("Expected Tx LOS on lane 2 only")
+	}
+	if s := c.Lanes[3].RxPowerState; s != common.AlarmStateLowWarning {
+		t.Errorf("Lane 4 Rx power state = %v, want %v", s, common.AlarmStateLowWarning)
+	}
+	if f := strings.Join(c.Lanes[3].Flags(), ", "); f != "Rx power "+common.AlarmStateLowWarning.String() {
+		t.Errorf("Lane 4 flags = %q", f)
+	}
+}
+
+func TestDecodeFlatMemory(t *testing.T) {
+	b := testEeprom()[:256]
+	c, err := Decode(b)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if c.Page01 != nil || c.Page11 != nil || c.Lanes != nil {
+		t.Errorf("Expected no optional pages for a 256 byte dump")
+	}
+	// Applications 1-8 are still known, without media lane assignment
+	if len(c.Applications) != 2 || c.Applications[0].MediaLaneAssignment != 0 {
+		t.Errorf("Unexpected applications %+v", c.Applications)
+	}
+
+	if _, err := Decode(make([]byte, 256)); err == nil {
+		t.Error("Expected error for non-CMIS identifier")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	c, err := Decode(testEeprom())
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	c.Page00.VendorPn[0] ^= 0x20
+	c.Page01.FirmwareVersion[1]++
+	c.Page02.Checksum++
+	var errs common.ChecksumErrors
+	if err := c.Validate(); !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("Validate() = %v, want page 00h and 02h mismatches", err)
+	}
+	// Bytes 128-129 are not covered by the page 01h checksum
+	if errs[0].Name != "Page 00h checksum" || errs[1].Name != "Page 02h checksum" {
+		t.Errorf("Unexpected mismatches %v", errs)
+	}
+
+	c.UpdateChecksums()
+	if err := c.Validate(); err != nil {
+		t.Errorf("Validate() after UpdateChecksums = %v, want nil", err)
+	}
+}
@@ -0,0 +1,215 @@
+package cmis
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"unsafe"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+// Page00 is the administrative information upper page 00h (bytes 128-255)
+type Page00 struct {
+	Identifier      common.Identifier `json:"identifier"`      // 128 - SFF-8024 Identifier
+	Vendor          common.String16   `json:"vendor"`          // 129-144 - Vendor name
+	VendorOui       common.VendorOUI  `json:"vendorOui"`       // 145-147 - Vendor OUI
+	VendorPn        common.String16   `json:"vendorPn"`        // 148-163 - Vendor PN
+	VendorRev       common.String2    `json:"vendorRev"`       // 164-165 - Vendor rev
+	VendorSn        common.String16   `json:"vendorSn"`        // 166-181 - Vendor SN
+	DateCode        common.DateCode   `json:"dateCode"`        // 182-189 - Date code
+	CleiCode        common.String10   `json:"cleiCode"`        // 190-199 - CLEI code
+	PowerClass      PowerClass        `json:"powerClass"`      // 200 - Module power class
+	MaxPower        MaxPower          `json:"maxPower"`        // 201 - Maximum power consumption
+	CableLength     CableLength       `json:"cableLength"`     // 202 - Cable assembly length
+	Connector       common.Connector  `json:"connector"`       // 203 - Media connector type
+	_               [6]byte           `json:"-"`               // 204-209 - Copper cable attenuation
+	MediaLaneInfo   MediaLaneInfo     `json:"mediaLaneInfo"`   // 210 - Supported media lanes
+	_               byte              `json:"-"`               // 211 - Cable assembly information
+	MediaTechnology MediaTechnology   `json:"mediaTechnology"` // 212 - Media interface technology
+	_               [9]byte           `json:"-"`               // 213-221 - Reserved
+	Checksum        byte              `json:"-"`               // 222 - Page checksum over bytes 128-221
+	_               [33]byte          `json:"-"`               // 223-255 - Custom
+}
+
+// bytes relies on Page00 being exactly one page. Fails to compile otherwise.
+var _ [common.PageLen]byte = [unsafe.Sizeof(Page00{})]byte{}
+
+func (p *Page00) bytes() []byte {
+	return (*[common.PageLen]byte)(unsafe.Pointer(p))[:]
+}
+
+// PowerClass is the module power class (byte 200 bits 7-5)
+type PowerClass byte
+
+// Class returns the power class 1-8
+func (p PowerClass) Class() int {
+	return int(p>>5) + 1
+}
+
+func (p PowerClass) String() string {
+	return fmt.Sprintf("Power Class %d", p.Class())
+}
+
+func (p PowerClass) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": p.Class(),
+		"hex":   hex.EncodeToString([]byte{byte(p)}),
+	}
+	return json.Marshal(m)
+}
+
+func (p *PowerClass) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in)
+	if err != nil {
+		return err
+	}
+	*p = PowerClass(b)
+	return nil
+}
+
+// MaxPower is the maximum power consumption in units of 0.25 W (byte 201)
+type MaxPower byte
+
+func (p MaxPower) Watts() float64 {
+	return float64(p) * 0.25
+}
+
+func (p MaxPower) String() string {
+	return fmt.Sprintf("%.2f W", p.Watts())
+}
+
+func (p MaxPower) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": p.Watts(),
+		"unit":  "W",
+		"hex":   hex.EncodeToString([]byte{byte(p)}),
+	}
+	return json.Marshal(m)
+}
+
+func (p *MaxPower) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in)
+	if err != nil {
+		return err
+	}
+	*p = MaxPower(b)
+	return nil
+}
+
+// CableLength is the cable assembly length (byte 202), a base length in bits
+// 5-0 scaled by the multiplier in bits 7-6
+type CableLength byte
+
+var cableLengthMultipliers = [4]float64{0.1, 1, 10, 100}
+
+// Meters returns the cable assembly length in meters, zero for separable media
+func (c CableLength) Meters() float64 {
+	return float64(c&0x3f) * cableLengthMultipliers[c>>6]
+}
+
+func (c CableLength) String() string {
+	return fmt.Sprintf("%g m", c.Meters())
+}
+
+func (c CableLength) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": c.Meters(),
+		"unit":  "m",
+		"hex":   hex.EncodeToString([]byte{byte(c)}),
+	}
+	return json.Marshal(m)
+}
+
+func (c *CableLength) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in)
+	if err != nil {
+		return err
+	}
+	*c = CableLength(b)
+	return nil
+}
+
+// MediaLaneInfo advertises the supported media lanes (byte 210). A set bit
+// means the lane is not supported.
+type MediaLaneInfo byte
+
+// Lanes returns the supported media lanes
+func (m MediaLaneInfo) Lanes() LaneMask {
+	return ^LaneMask(m)
+}
+
+func (m MediaLaneInfo) String() string {
+	return m.Lanes().String()
+}
+
+func (m MediaLaneInfo) MarshalJSON() ([]byte, error) {
+	v := map[string]interface{}{
+		"value": m.Lanes().List(),
+		"hex":   hex.EncodeToString([]byte{byte(m)}),
+	}
+	return json.Marshal(v)
+}
+
+func (m *MediaLaneInfo) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in)
+	if err != nil {
+		return err
+	}
+	*m = MediaLaneInfo(b)
+	return nil
+}
+
+// MediaTechnology is the media interface technology (byte 212)
+type MediaTechnology byte
+
+var mediaTechnologyNames = map[MediaTechnology]string{
+	0x00: "850 nm VCSEL",
+	0x01: "1310 nm VCSEL",
+	0x02: "1550 nm VCSEL",
+	0x03: "1310 nm FP",
+	0x04: "1310 nm DFB",
+	0x05: "1550 nm DFB",
+	0x06: "1310 nm EML",
+	0x07: "1550 nm EML",
+	0x08: "Others",
+	0x09: "1490 nm DFB",
+	0x0A: "Copper cable unequalized",
+	0x0B: "Copper cable passive equalized",
+	0x0C: "Copper cable, near and far end limiting active equalizers",
+	0x0D: "Copper cable, far end limiting active equalizers",
+	0x0E: "Copper cable, near end limiting active equalizers",
+	0x0F: "Copper cable, linear active equalizers",
+	0x10: "C-band tunable laser",
+	0x11: "L-band tunable laser",
+}
+
+func (m MediaTechnology) String() string {
+	n, ok := mediaTechnologyNames[m]
+	if !ok {
+		return fmt.Sprintf("Reserved (0x%02x)", byte(m))
+	}
+	return n
+}
+
+// IsCopper returns true for copper cable technologies
+func (m MediaTechnology) IsCopper() bool {
+	return m >= 0x0A && m <= 0x0F
+}
+
+func (m MediaTechnology) MarshalJSON() ([]byte, error) {
+	v := map[string]interface{}{
+		"value": m.String(),
+		"hex":   hex.EncodeToString([]byte{byte(m)}),
+	}
+	return json.Marshal(v)
+}
+
+func (m *MediaTechnology) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in)
+	if err != nil {
+		return err
+	}
+	*m = MediaTechnology(b)
+	return nil
+}
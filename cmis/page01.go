@@ -0,0 +1,272 @@
+package cmis
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+// Page01 is the advertising upper page 01h (bytes 128-255)
+type Page01 struct {
+	FirmwareVersion     Version                      `json:"firmwareVersion"`     // 128-129 - Active firmware version
+	HardwareRevision    Version                      `json:"hardwareRevision"`    // 130-131 - Hardware revision
+	LinkLengths         LinkLengths                  `json:"linkLengths"`         // 132-137 - Supported link lengths
+	Wavelength          common.WavelengthNanometerBE `json:"wavelength"`          // 138-139 - Nominal wavelength
+	WavelengthTolerance common.ToleranceNanometerBE  `json:"wavelengthTolerance"` // 140-141 - Wavelength tolerance
+	PagesSupported      PagesSupported               `json:"pagesSupported"`      // 142 - Supported pages and banks
+	_                   [16]byte                     `json:"-"`                   // 143-158 - Durations and module characteristics
+	MonitorSupport      MonitorSupport               `json:"monitorSupport"`      // 159-160 - Implemented monitors
+	_                   [15]byte                     `json:"-"`                   // 161-175 - Implemented controls and flags
+	MediaLaneAssignment [15]LaneMask                 `json:"-"`                   // 176-190 - Media lane assignment of applications 1-15
+	_                   [32]byte                     `json:"-"`                   // 191-222 - Signal integrity and CDB advertisement
+	Applications        [7]ApplicationDescriptor     `json:"-"`                   // 223-250 - Application descriptors 9-15
+	_                   [4]byte                      `json:"-"`                   // 251-254 - Reserved
+	Checksum            byte                         `json:"-"`                   // 255 - Page checksum over bytes 130-254
+}
+
+// bytes relies on Page01 being exactly one page. Fails to compile otherwise.
+var _ [common.PageLen]byte = [unsafe.Sizeof(Page01{})]byte{}
+
+func (p *Page01) bytes() []byte {
+	return (*[common.PageLen]byte)(unsafe.Pointer(p))[:]
+}
+
+// Version is a major and minor version number
+type Version [2]byte
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d", v[0], v[1])
+}
+
+func (v Version) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": v.String(),
+		"hex":   hex.EncodeToString(v[:]),
+	}
+	return json.Marshal(m)
+}
+
+func (v *Version) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < 2 {
+		return fmt.Errorf("length is shorter then Version type")
+	}
+
+	*v = Version{b[0], b[1]}
+	return nil
+}
+
+// LinkLengths advertises the supported link lengths per fiber type (bytes
+// 132-136, byte 137 is reserved)
+type LinkLengths [6]byte
+
+// Smf returns the supported single mode fiber length in meters. The base
+// length in bits 5-0 of byte 132 is scaled by 0.1 km or 1 km (bits 7-6).
+func (l LinkLengths) Smf() float64 {
+	m := 100.0
+	if l[0]>>6 == 0x01 {
+		m = 1000
+	}
+	return float64(l[0]&0x3f) * m
+}
+
+// Om5 returns the supported OM5 fiber length in meters (byte 133, units of 2 m)
+func (l LinkLengths) Om5() float64 {
+	return float64(l[1]) * 2
+}
+
+// Om4 returns the supported OM4 fiber length in meters (byte 134, units of 2 m)
+func (l LinkLengths) Om4() float64 {
+	return float64(l[2]) * 2
+}
+
+// Om3 returns the supported OM3 fiber length in meters (byte 135, units of 2 m)
+func (l LinkLengths) Om3() float64 {
+	return float64(l[3]) * 2
+}
+
+// Om2 returns the supported OM2 fiber length in meters (byte 136, units of 1 m)
+func (l LinkLengths) Om2() float64 {
+	return float64(l[4])
+}
+
+func (l LinkLengths) String() string {
+	return fmt.Sprintf("SMF %g m, OM5 %g m, OM4 %g m, OM3 %g m, OM2 %g m", l.Smf(), l.Om5(), l.Om4(), l.Om3(), l.Om2())
+}
+
+func (l LinkLengths) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"smf": l.Smf(),
+		"om5": l.Om5(),
+		"om4": l.Om4(),
+		"om3": l.Om3(),
+		"om2": l.Om2(),
+		"hex": hex.EncodeToString(l[:]),
+	}
+	return json.Marshal(m)
+}
+
+func (l *LinkLengths) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < len(l) {
+		return fmt.Errorf("length is shorter then LinkLengths type")
+	}
+
+	copy(l[:], b)
+	return nil
+}
+
+// PagesSupported advertises the optional pages and banks (byte 142)
+type PagesSupported byte
+
+// Banks returns the number of supported banks (bits 1-0)
+func (p PagesSupported) Banks() int {
+	switch p & 0x03 {
+	case 0x01:
+		return 2
+	case 0x02:
+		return 4
+	}
+	return 1
+}
+
+func (p PagesSupported) String() string {
+	return fmt.Sprintf("%d bank(s)", p.Banks())
+}
+
+func (p PagesSupported) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"banks": p.Banks(),
+		"hex":   hex.EncodeToString([]byte{byte(p)}),
+	}
+	return json.Marshal(m)
+}
+
+func (p *PagesSupported) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in)
+	if err != nil {
+		return err
+	}
+	*p = PagesSupported(b)
+	return nil
+}
+
+// MonitorSupport advertises the implemented monitors (bytes 159-160)
+type MonitorSupport [2]byte
+
+// IsTemperatureSupported returns true if the temperature monitor is implemented (byte 159 bit 0)
+func (m MonitorSupport) IsTemperatureSupported() bool {
+	return m[0]&0x01 != 0
+}
+
+// IsVccSupported returns true if the supply voltage monitor is implemented (byte 159 bit 1)
+func (m MonitorSupport) IsVccSupported() bool {
+	return m[0]&0x02 != 0
+}
+
+// IsTxBiasSupported returns true if Tx bias monitors are implemented (byte 160 bit 0)
+func (m MonitorSupport) IsTxBiasSupported() bool {
+	return m[1]&0x01 != 0
+}
+
+// IsTxPowerSupported returns true if Tx output power monitors are implemented (byte 160 bit 1)
+func (m MonitorSupport) IsTxPowerSupported() bool {
+	return m[1]&0x02 != 0
+}
+
+// IsRxPowerSupported returns true if Rx input power monitors are implemented (byte 160 bit 2)
+func (m MonitorSupport) IsRxPowerSupported() bool {
+	return m[1]&0x04 != 0
+}
+
+// TxBiasMultiplier returns the scaling of the Tx bias monitors and thresholds
+// (byte 160 bits 4-3). One LSB is 2 µA times the multiplier.
+func (m MonitorSupport) TxBiasMultiplier() int {
+	switch m[1] >> 3 & 0x03 {
+	case 0x01:
+		return 2
+	case 0x02:
+		return 4
+	}
+	return 1
+}
+
+func (m MonitorSupport) List() []string {
+	var l []string
+	if m.IsTemperatureSupported() {
+		l = append(l, "Temperature")
+	}
+	if m.IsVccSupported() {
+		l = append(l, "Vcc")
+	}
+	if m.IsTxBiasSupported() {
+		l = append(l, fmt.Sprintf("Tx bias (x%d)", m.TxBiasMultiplier()))
+	}
+	if m.IsTxPowerSupported() {
+		l = append(l, "Tx power")
+	}
+	if m.IsRxPowerSupported() {
+		l = append(l, "Rx power")
+	}
+	return l
+}
+
+func (m MonitorSupport) String() string {
+	l := m.List()
+	if len(l) == 0 {
+		return "None"
+	}
+	return strings.Join(l, ", ")
+}
+
+func (m MonitorSupport) MarshalJSON() ([]byte, error) {
+	v := map[string]interface{}{
+		"value": m.List(),
+		"hex":   hex.EncodeToString(m[:]),
+	}
+	return json.Marshal(v)
+}
+
+func (m *MonitorSupport) UnmarshalJSON(in []byte) error {
+	v := map[string]interface{}{}
+	err := json.Unmarshal(in, &v)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(v["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < 2 {
+		return fmt.Errorf("length is shorter then MonitorSupport type")
+	}
+
+	*m = MonitorSupport{b[0], b[1]}
+	return nil
+}
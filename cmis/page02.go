@@ -0,0 +1,28 @@
+package cmis
+
+import (
+	"unsafe"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+// Page02 holds the module and lane monitor thresholds of upper page 02h
+// (bytes 128-255). Tx bias thresholds are scaled by
+// MonitorSupport.TxBiasMultiplier.
+type Page02 struct {
+	TempThresholds    common.TemperatureThresholds `json:"tempThresholds"`    // 128-135 - Temperature
+	VccThresholds     common.VoltageThresholds     `json:"vccThresholds"`     // 136-143 - Supply voltage
+	_                 [32]byte                     `json:"-"`                 // 144-175 - Aux and custom monitors
+	TxPowerThresholds common.PowerThresholds       `json:"txPowerThresholds"` // 176-183 - Tx optical power
+	TxBiasThresholds  common.CurrentThresholds     `json:"txBiasThresholds"`  // 184-191 - Tx bias current
+	RxPowerThresholds common.PowerThresholds       `json:"rxPowerThresholds"` // 192-199 - Rx optical power
+	_                 [55]byte                     `json:"-"`                 // 200-254 - Reserved
+	Checksum          byte                         `json:"-"`                 // 255 - Page checksum over bytes 128-254
+}
+
+// bytes relies on Page02 being exactly one page. Fails to compile otherwise.
+var _ [common.PageLen]byte = [unsafe.Sizeof(Page02{})]byte{}
+
+func (p *Page02) bytes() []byte {
+	return (*[common.PageLen]byte)(unsafe.Pointer(p))[:]
+}
@@ -0,0 +1,294 @@
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// HostInterfaceID is a host electrical interface code from SFF-8024 Table 4-5,
+// as used by CMIS application descriptors
+type HostInterfaceID byte
+
+const (
+	HostInterfaceUndefined = 0x00
+	HostInterfaceEndOfList = 0xFF // Marks the end of the application list
+)
+
+var hostInterfaceNames = map[byte]string{
+	0x00: "Undefined",
+	0x01: "1000BASE-CX (Clause 39)",
+	0x02: "XAUI (Clause 47)",
+	0x03: "XFI (SFF INF-8071i)",
+	0x04: "SFI (SFF-8431)",
+	0x05: "25GAUI C2M (Annex 109B)",
+	0x06: "XLAUI C2M (Annex 83B)",
+	0x07: "XLPPI (Annex 86A)",
+	0x08: "LAUI-2 C2M (Annex 135C)",
+	0x09: "50GAUI-2 C2M (Annex 135E)",
+	0x0A: "50GAUI-1 C2M (Annex 135G)",
+	0x0B: "CAUI-4 C2M (Annex 83E)",
+	0x0C: "100GAUI-4 C2M (Annex 135E)",
+	0x0D: "100GAUI-2 C2M (Annex 135G)",
+	0x0E: "200GAUI-8 C2M (Annex 120C)",
+	0x0F: "200GAUI-4 C2M (Annex 120E)",
+	0x10: "400GAUI-16 C2M (Annex 120C)",
+	0x11: "400GAUI-8 C2M (Annex 120E)",
+	0x13: "10GBASE-CX4 (Clause 54)",
+	0x14: "25GBASE-CR CA-L (Clause 110)",
+	0x15: "25GBASE-CR CA-S (Clause 110)",
+	0x16: "25GBASE-CR CA-N (Clause 110)",
+	0x17: "40GBASE-CR4 (Clause 85)",
+	0x18: "50GBASE-CR (Clause 126)",
+	0x1A: "100GBASE-CR4 (Clause 92)",
+	0x1B: "100GBASE-CR2 (Clause 136)",
+	0x1C: "200GBASE-CR4 (Clause 136)",
+	0x1D: "400G CR8",
+	0x1F: "8GFC (FC-PI-4)",
+	0x20: "10GFC (10GFC)",
+	0x21: "16GFC (FC-PI-5)",
+	0x22: "32GFC (FC-PI-6)",
+	0x23: "64GFC (FC-PI-7)",
+	0x24: "128GFC (FC-PI-6P)",
+	0x25: "256GFC (FC-PI-7P)",
+	0x26: "IB SDR",
+	0x27: "IB DDR",
+	0x28: "IB QDR",
+	0x29: "IB FDR",
+	0x2A: "IB EDR",
+	0x2B: "IB HDR",
+	0x2C: "IB NDR",
+	0x2D: "E.96 (CPRI)",
+	0x2E: "E.99 (CPRI)",
+	0x2F: "E.119 (CPRI)",
+	0x30: "E.238 (CPRI)",
+	0x31: "OTL3.4 (ITU-T G.709/Y.1331 G.Sup58)",
+	0x32: "OTL4.10 (ITU-T G.709/Y.1331 G.Sup58)",
+	0x33: "OTL4.4 (ITU-T G.709/Y.1331 G.Sup58)",
+	0x34: "OTLC.4 (ITU-T G.709.1/Y.1331 G.Sup58)",
+	0x35: "FOIC1.4 (ITU-T G.709.1/Y.1331 G.Sup58)",
+	0x36: "FOIC1.2 (ITU-T G.709.1/Y.1331 G.Sup58)",
+	0x37: "FOIC2.8 (ITU-T G.709.1/Y.1331 G.Sup58)",
+	0x38: "FOIC2.4 (ITU-T G.709.1/Y.1331 G.Sup58)",
+	0x39: "FOIC4.16 (ITU-T G.709.1 G.Sup58)",
+	0x3A: "FOIC4.8 (ITU-T G.709.1 G.Sup58)",
+	0xFF: "End of list",
+}
+
+func (h HostInterfaceID) String() string {
+	n, ok := hostInterfaceNames[byte(h)]
+	if !ok {
+		return fmt.Sprintf("Reserved or unknown (0x%02x)", byte(h))
+	}
+	return n
+}
+
+func (h HostInterfaceID) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": h.String(),
+		"hex":   hex.EncodeToString([]byte{byte(h)}),
+	}
+	return json.Marshal(m)
+}
+
+func (h *HostInterfaceID) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*h = HostInterfaceID(b[0])
+	return nil
+}
+
+// MediaType selects the SFF-8024 table media interface codes are taken from
+// (CMIS lower page byte 85)
+type MediaType byte
+
+const (
+	MediaTypeUndefined     = 0x00
+	MediaTypeMmf           = 0x01
+	MediaTypeSmf           = 0x02
+	MediaTypePassiveCopper = 0x03
+	MediaTypeActiveCable   = 0x04
+	MediaTypeBaseT         = 0x05
+)
+
+var mediaTypeNames = map[byte]string{
+	MediaTypeUndefined:     "Undefined",
+	MediaTypeMmf:           "Optical Interfaces: MMF",
+	MediaTypeSmf:           "Optical Interfaces: SMF",
+	MediaTypePassiveCopper: "Passive Copper Cables",
+	MediaTypeActiveCable:   "Active Cables",
+	MediaTypeBaseT:         "BASE-T",
+}
+
+func (t MediaType) String() string {
+	n, ok := mediaTypeNames[byte(t)]
+	if ok {
+		return n
+	}
+	if t >= 0x40 && t <= 0x8F {
+		return fmt.Sprintf("Custom (0x%02x)", byte(t))
+	}
+	return fmt.Sprintf("Reserved (0x%02x)", byte(t))
+}
+
+func (t MediaType) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": t.String(),
+		"hex":   hex.EncodeToString([]byte{byte(t)}),
+	}
+	return json.Marshal(m)
+}
+
+func (t *MediaType) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*t = MediaType(b[0])
+	return nil
+}
+
+// MMF media interface codes, SFF-8024 Table 4-6
+var mmfMediaInterfaceNames = map[byte]string{
+	0x00: "Undefined",
+	0x01: "10GBASE-SW (Clause 52)",
+	0x02: "10GBASE-SR (Clause 52)",
+	0x03: "25GBASE-SR (Clause 112)",
+	0x04: "40GBASE-SR4 (Clause 86)",
+	0x05: "40GE SWDM4 MSA Spec",
+	0x06: "40GE BiDi",
+	0x07: "50GBASE-SR (Clause 138)",
+	0x08: "100GBASE-SR10 (Clause 86)",
+	0x09: "100GBASE-SR4 (Clause 95)",
+	0x0A: "100GE SWDM4 MSA Spec",
+	0x0B: "100GE BiDi",
+	0x0C: "100GBASE-SR2 (Clause 138)",
+	0x0D: "100G-SR",
+	0x0E: "200GBASE-SR4 (Clause 138)",
+	0x0F: "400GBASE-SR16 (Clause 123)",
+	0x10: "400GBASE-SR8 (Clause 138)",
+	0x11: "400G-SR4",
+	0x12: "800G-SR8",
+	0x13: "8GFC-MM (FC-PI-4)",
+	0x14: "10GFC-MM (10GFC)",
+	0x15: "16GFC-MM (FC-PI-5)",
+	0x16: "32GFC-MM (FC-PI-6)",
+	0x17: "64GFC-MM (FC-PI-7)",
+	0x18: "128GFC-MM4 (FC-PI-6P)",
+	0x19: "256GFC-SW4 (FC-PI-7P)",
+	0x1A: "400GBASE-SR4.2 (Clause 150)",
+}
+
+// SMF media interface codes, SFF-8024 Table 4-7
+var smfMediaInterfaceNames = map[byte]string{
+	0x00: "Undefined",
+	0x01: "10GBASE-LW (Clause 52)",
+	0x02: "10GBASE-EW (Clause 52)",
+	0x03: "10G-ZW",
+	0x04: "10GBASE-LR (Clause 52)",
+	0x05: "10GBASE-ER (Clause 52)",
+	0x06: "10G-ZR",
+	0x07: "25GBASE-LR (Clause 114)",
+	0x08: "25GBASE-ER (Clause 114)",
+	0x09: "40GBASE-LR4 (Clause 87)",
+	0x0A: "40GBASE-FR (Clause 89)",
+	0x0B: "50GBASE-FR (Clause 139)",
+	0x0C: "50GBASE-LR (Clause 139)",
+	0x0D: "100GBASE-LR4 (Clause 88)",
+	0x0E: "100GBASE-ER4 (Clause 88)",
+	0x0F: "100G PSM4 MSA Spec",
+	0x10: "100G CWDM4 MSA Spec",
+	0x11: "100G 4WDM-10 MSA Spec",
+	0x12: "100G 4WDM-20 MSA Spec",
+	0x13: "100G 4WDM-40 MSA Spec",
+	0x14: "100GBASE-DR (Clause 140)",
+	0x15: "100G-FR/100GBASE-FR1 (Clause 140)",
+	0x16: "100G-LR/100GBASE-LR1 (Clause 140)",
+	0x17: "200GBASE-DR4 (Clause 121)",
+	0x18: "200GBASE-FR4 (Clause 122)",
+	0x19: "200GBASE-LR4 (Clause 122)",
+	0x1A: "400GBASE-FR8 (Clause 122)",
+	0x1B: "400GBASE-LR8 (Clause 122)",
+	0x1C: "400GBASE-DR4 (Clause 124)",
+	0x1D: "400G-FR4/400GBASE-FR4 (Clause 151)",
+	0x1E: "400G-LR4-10",
+	0x1F: "8GFC-SM (FC-PI-4)",
+	0x20: "10GFC-SM (10GFC)",
+	0x21: "16GFC-SM (FC-PI-5)",
+	0x22: "32GFC-SM (FC-PI-6)",
+	0x23: "64GFC-SM (FC-PI-7)",
+	0x24: "128GFC-PSM4 (FC-PI-6P)",
+	0x25: "256GFC-PSM4 (FC-PI-7P)",
+	0x26: "128GFC-CWDM4 (FC-PI-6P)",
+	0x27: "256GFC-CWDM4 (FC-PI-7P)",
+	0x2C: "4I1-9D1F (ITU-T G.959.1)",
+	0x2D: "4L1-9C1F (ITU-T G.959.1)",
+	0x2E: "4L1-9D1F (ITU-T G.959.1)",
+	0x2F: "C4S1-9D1F (ITU-T G.695)",
+	0x30: "C4S1-4D1F (ITU-T G.695)",
+	0x31: "4I1-4D1F (ITU-T G.959.1)",
+	0x32: "8R1-4D1F (ITU-T G.959.1)",
+	0x33: "8I1-4D1F (ITU-T G.959.1)",
+	0x3E: "400ZR, DWDM, amplified",
+	0x3F: "400ZR, Single Wavelength, Unamplified",
+}
+
+// Passive copper cable media interface codes, SFF-8024 Table 4-8
+var passiveCopperMediaInterfaceNames = map[byte]string{
+	0x00: "Undefined",
+	0x01: "Copper cable",
+	0xBF: "Passive Loopback module",
+}
+
+// Active cable assembly media interface codes, SFF-8024 Table 4-9
+var activeCableMediaInterfaceNames = map[byte]string{
+	0x00: "Undefined",
+	0x01: "Active Cable assembly with BER < 1e-12",
+	0x02: "Active Cable assembly with BER < 5e-5",
+	0x03: "Active Cable assembly with BER < 2.6e-4",
+	0x04: "Active Cable assembly with BER < 1e-6",
+	0xBF: "Active Loopback module",
+}
+
+// BASE-T media interface codes, SFF-8024 Table 4-10
+var baseTMediaInterfaceNames = map[byte]string{
+	0x00: "Undefined",
+	0x01: "1000BASE-T (Clause 40)",
+	0x02: "2.5GBASE-T (Clause 126)",
+	0x03: "5GBASE-T (Clause 126)",
+	0x04: "10GBASE-T (Clause 55)",
+}
+
+var mediaInterfaceNames = map[byte]map[byte]string{
+	MediaTypeMmf:           mmfMediaInterfaceNames,
+	MediaTypeSmf:           smfMediaInterfaceNames,
+	MediaTypePassiveCopper: passiveCopperMediaInterfaceNames,
+	MediaTypeActiveCable:   activeCableMediaInterfaceNames,
+	MediaTypeBaseT:         baseTMediaInterfaceNames,
+}
+
+// MediaInterfaceName returns the name of media interface code id, which is
+// interpreted according to the media type t
+func MediaInterfaceName(t MediaType, id byte) string {
+	n, ok := mediaInterfaceNames[byte(t)][id]
+	if !ok {
+		return fmt.Sprintf("Reserved or unknown (0x%02x)", id)
+	}
+	return n
+}
@@ -0,0 +1,102 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Checksum returns the low order 8 bits of the sum of b, as used by the
+// CC_BASE, CC_EXT and CC_DMI check codes
+func Checksum(b []byte) byte {
+	var sum byte
+	for _, v := range b {
+		sum += v
+	}
+	return sum
+}
+
+// CheckCode describes a check code byte at Offset covering bytes Start up to
+// but not including Offset
+type CheckCode struct {
+	Name   string // e.g. CC_BASE
+	Start  int    // First covered byte
+	Offset int    // Offset of the check code byte
+}
+
+// Compute returns the check code for b
+func (c CheckCode) Compute(b []byte) byte {
+	return Checksum(b[c.Start:c.Offset])
+}
+
+// Verify returns a *ChecksumError if the check code stored in b does not match
+func (c CheckCode) Verify(b []byte) error {
+	if sum := c.Compute(b); sum != b[c.Offset] {
+		return &ChecksumError{Name: c.Name, Start: c.Start, Offset: c.Offset, Stored: b[c.Offset], Computed: sum}
+	}
+	return nil
+}
+
+// Update stores the computed check code in b
+func (c CheckCode) Update(b []byte) {
+	b[c.Offset] = c.Compute(b)
+}
+
+// Status describes the check code stored in b, for display
+func (c CheckCode) Status(b []byte) string {
+	if err := c.Verify(b); err != nil {
+		return fmt.Sprintf("0x%02x (Invalid, computed 0x%02x)", b[c.Offset], c.Compute(b))
+	}
+	return fmt.Sprintf("0x%02x (OK)", b[c.Offset])
+}
+
+// ChecksumError reports a check code that does not match the bytes it covers
+type ChecksumError struct {
+	Name     string // Check code name
+	Start    int    // First covered byte
+	Offset   int    // Offset of the check code byte
+	Stored   byte   // Check code read from the module
+	Computed byte   // Check code computed over bytes Start to Offset-1
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("%s [%d] mismatch over bytes %d-%d: stored 0x%02x, computed 0x%02x",
+		e.Name, e.Offset, e.Start, e.Offset-1, e.Stored, e.Computed)
+}
+
+// ChecksumErrors is returned when more than one check code mismatches
+type ChecksumErrors []*ChecksumError
+
+func (e ChecksumErrors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+// Unwrap allows errors.As to find the individual *ChecksumError values
+func (e ChecksumErrors) Unwrap() []error {
+	r := make([]error, len(e))
+	for i, err := range e {
+		r[i] = err
+	}
+	return r
+}
+
+// VerifyCheckCodes verifies all codes against b. It returns nil, a single
+// *ChecksumError or ChecksumErrors.
+func VerifyCheckCodes(b []byte, codes ...CheckCode) error {
+	var errs ChecksumErrors
+	for _, c := range codes {
+		if err := c.Verify(b); err != nil {
+			errs = append(errs, err.(*ChecksumError))
+		}
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return errs
+}
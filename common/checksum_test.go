@@ -0,0 +1,34 @@
+package common
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckCodes(t *testing.T) {
+	base := CheckCode{Name: "CC_BASE", Start: 0, Offset: 3}
+	ext := CheckCode{Name: "CC_EXT", Start: 4, Offset: 6}
+	b := []byte{0x80, 0x90, 0x01, 0x11, 0x02, 0x03, 0x05}
+
+	if err := VerifyCheckCodes(b, base, ext); err != nil {
+		t.Fatalf("VerifyCheckCodes() = %v, want nil", err)
+	}
+
+	b[0] = 0x81
+	var cerr *ChecksumError
+	if err := VerifyCheckCodes(b, base, ext); !errors.As(err, &cerr) || cerr.Name != "CC_BASE" || cerr.Stored != 0x11 || cerr.Computed != 0x12 {
+		t.Fatalf("VerifyCheckCodes() = %v, want CC_BASE mismatch", err)
+	}
+
+	b[6] = 0
+	err := VerifyCheckCodes(b, base, ext)
+	if errs, ok := err.(ChecksumErrors); !ok || len(errs) != 2 {
+		t.Fatalf("VerifyCheckCodes() = %v, want two mismatches", err)
+	}
+
+	base.Update(b)
+	ext.Update(b)
+	if err := VerifyCheckCodes(b, base, ext); err != nil {
+		t.Errorf("VerifyCheckCodes() after Update = %v, want nil", err)
+	}
+}
@@ -0,0 +1,13 @@
+package common
+
+// ANSI colors of the StringCol output
+const (
+	ColorRed     = "\x1b[31m"
+	ColorGreen   = "\x1b[32m"
+	ColorYellow  = "\x1b[33m"
+	ColorBlue    = "\x1b[34m"
+	ColorMagenta = "\x1b[35m"
+	ColorCyan    = "\x1b[36m"
+	ColorWhite   = "\x1b[37m"
+	ColorClear   = "\x1b[0m"
+)
@@ -0,0 +1,210 @@
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Extended specification compliance codes, SFF-8024 Table 4-4. Referenced by
+// SFF-8472 byte 36 and SFF-8636 byte 192.
+const (
+	ExtComplianceUnspecified      = 0x00
+	ExtCompliance100gAoc5e5       = 0x01
+	ExtCompliance100gBaseSr4      = 0x02
+	ExtCompliance100gBaseLr4      = 0x03
+	ExtCompliance100gBaseEr4      = 0x04
+	ExtCompliance100gBaseSr10     = 0x05
+	ExtCompliance100gCwdm4        = 0x06
+	ExtCompliance100gPsm4         = 0x07
+	ExtCompliance100gAcc5e5       = 0x08
+	ExtComplianceObsolete09       = 0x09
+	ExtCompliance100gBaseCr4      = 0x0B
+	ExtCompliance25gBaseCrS       = 0x0C
+	ExtCompliance25gBaseCrN       = 0x0D
+	ExtCompliance10mSpe           = 0x0E
+	ExtCompliance40gBaseEr4       = 0x10
+	ExtCompliance4x10gBaseSr      = 0x11
+	ExtCompliance40gPsm4          = 0x12
+	ExtComplianceG959P1i12d1      = 0x13
+	ExtComplianceG959P1s12d2      = 0x14
+	ExtComplianceG959P1l12d2      = 0x15
+	ExtCompliance10gBaseTSfi      = 0x16
+	ExtCompliance100gClr4         = 0x17
+	ExtCompliance100gAoc1e12      = 0x18
+	ExtCompliance100gAcc1e12      = 0x19
+	ExtCompliance100geDwdm2       = 0x1A
+	ExtCompliance100gWdm1550      = 0x1B
+	ExtCompliance10gBaseTSr       = 0x1C
+	ExtCompliance5gBaseT          = 0x1D
+	ExtCompliance2500BaseT        = 0x1E
+	ExtCompliance40gSwdm4         = 0x1F
+	ExtCompliance100gSwdm4        = 0x20
+	ExtCompliance100gPam4Bidi     = 0x21
+	ExtCompliance4wdm10           = 0x22
+	ExtCompliance4wdm20           = 0x23
+	ExtCompliance4wdm40           = 0x24
+	ExtCompliance100gBaseDr       = 0x25
+	ExtCompliance100gFr           = 0x26
+	ExtCompliance100gLr           = 0x27
+	ExtCompliance100gBaseSr1Caui4 = 0x28
+	ExtCompliance100gBaseSr1      = 0x29
+	ExtCompliance100gBaseFr1      = 0x2A
+	ExtCompliance100gBaseLr1      = 0x2B
+	ExtCompliance100gLr120Caui4   = 0x2C
+	ExtCompliance100gEr130Caui4   = 0x2D
+	ExtCompliance100gEr140Caui4   = 0x2E
+	ExtCompliance100gLr120        = 0x2F
+	ExtComplianceAcc50gaui1e6     = 0x30
+	ExtComplianceAoc50gaui1e6     = 0x31
+	ExtComplianceAcc50gaui26e4    = 0x32
+	ExtComplianceAoc50gaui26e4    = 0x33
+	ExtCompliance100gEr130        = 0x34
+	ExtCompliance100gEr140        = 0x35
+	ExtCompliance100gBaseVr1      = 0x36
+	ExtCompliance10gBaseBr        = 0x37
+	ExtCompliance25gBaseBr        = 0x38
+	ExtCompliance50gBaseBr        = 0x39
+	ExtCompliance100gBaseVr1Caui4 = 0x3A
+	ExtCompliance100gBaseCr1      = 0x3F
+	ExtCompliance50gBaseCr        = 0x40
+	ExtCompliance50gBaseSr        = 0x41
+	ExtCompliance50gBaseFr        = 0x42
+	ExtCompliance200gBaseFr4      = 0x43
+	ExtCompliance200gPsm4         = 0x44
+	ExtCompliance50gBaseLr        = 0x45
+	ExtCompliance200gBaseLr4      = 0x46
+	ExtCompliance400gBaseDr4      = 0x47
+	ExtCompliance400gBaseFr4      = 0x48
+	ExtCompliance400gBaseLr46     = 0x49
+	ExtCompliance50gBaseEr        = 0x4A
+	ExtCompliance400gLr410        = 0x4B
+	ExtCompliance400gBaseZr       = 0x4C
+	ExtCompliance256gfcSw4        = 0x7F
+	ExtCompliance64gfc            = 0x80
+	ExtCompliance128gfc           = 0x81
+)
+
+var extComplianceNames = codeTable{
+	ExtComplianceUnspecified:      {"Unspecified", "Unspecified"},
+	ExtCompliance100gAoc5e5:       {"100G AOC", "100G AOC or 25GAUI C2M AOC with worst BER of 5x10^(-5)"},
+	ExtCompliance100gBaseSr4:      {"100GBASE-SR4", "100GBASE-SR4 or 25GBASE-SR"},
+	ExtCompliance100gBaseLr4:      {"100GBASE-LR4", "100GBASE-LR4 or 25GBASE-LR"},
+	ExtCompliance100gBaseEr4:      {"100GBASE-ER4", "100GBASE-ER4 or 25GBASE-ER"},
+	ExtCompliance100gBaseSr10:     {"100GBASE-SR10", "100GBASE-SR10"},
+	ExtCompliance100gCwdm4:        {"100G CWDM4", "100G CWDM4"},
+	ExtCompliance100gPsm4:         {"100G PSM4", "100G PSM4 Parallel SMF"},
+	ExtCompliance100gAcc5e5:       {"100G ACC", "100G ACC or 25GAUI C2M ACC with worst BER of 5x10^(-5)"},
+	ExtComplianceObsolete09:       {"Obsolete", "Obsolete (100G CWDM4 MSA without FEC)"},
+	ExtCompliance100gBaseCr4:      {"100GBASE-CR4", "100GBASE-CR4, 25GBASE-CR CA-25G-L or 50GBASE-CR2 with RS FEC"},
+	ExtCompliance25gBaseCrS:       {"25GBASE-CR CA-S", "25GBASE-CR CA-25G-S or 50GBASE-CR2 with BASE-R FEC"},
+	ExtCompliance25gBaseCrN:       {"25GBASE-CR CA-N", "25GBASE-CR CA-25G-N or 50GBASE-CR2 with no FEC"},
+	ExtCompliance10mSpe:           {"10M SPE", "10 Mb/s Single Pair Ethernet (802.3cg, Clause 146/147)"},
+	ExtCompliance40gBaseEr4:       {"40GBASE-ER4", "40GBASE-ER4"},
+	ExtCompliance4x10gBaseSr:      {"4x10GBASE-SR", "4 x 10GBASE-SR"},
+	ExtCompliance40gPsm4:          {"40G PSM4", "40G PSM4 Parallel SMF"},
+	ExtComplianceG959P1i12d1:      {"G959.1 P1I1-2D1", "G959.1 profile P1I1-2D1 (10709 MBd, 2km, 1310 nm SM)"},
+	ExtComplianceG959P1s12d2:      {"G959.1 P1S1-2D2", "G959.1 profile P1S1-2D2 (10709 MBd, 40km, 1550 nm SM)"},
+	ExtComplianceG959P1l12d2:      {"G959.1 P1L1-2D2", "G959.1 profile P1L1-2D2 (10709 MBd, 80km, 1550 nm SM)"},
+	ExtCompliance10gBaseTSfi:      {"10GBASE-T SFI", "10GBASE-T with SFI electrical interface"},
+	ExtCompliance100gClr4:         {"100G CLR4", "100G CLR4"},
+	ExtCompliance100gAoc1e12:      {"100G AOC", "100G AOC or 25GAUI C2M AOC with worst BER of 10^(-12)"},
+	ExtCompliance100gAcc1e12:      {"100G ACC", "100G ACC or 25GAUI C2M ACC with worst BER of 10^(-12)"},
+	ExtCompliance100geDwdm2:       {"100GE-DWDM2", "100GE-DWDM2"},
+	ExtCompliance100gWdm1550:      {"100G 1550nm WDM", "100G 1550nm WDM (4 wavelengths)"},
+	ExtCompliance10gBaseTSr:       {"10GBASE-T SR", "10GBASE-T Short Reach (30 meters)"},
+	ExtCompliance5gBaseT:          {"5GBASE-T", "5GBASE-T"},
+	ExtCompliance2500BaseT:        {"2.5GBASE-T", "2.5GBASE-T"},
+	ExtCompliance40gSwdm4:         {"40G SWDM4", "40G SWDM4"},
+	ExtCompliance100gSwdm4:        {"100G SWDM4", "100G SWDM4"},
+	ExtCompliance100gPam4Bidi:     {"100G PAM4 BiDi", "100G PAM4 BiDi"},
+	ExtCompliance4wdm10:           {"4WDM-10", "4WDM-10 MSA (10km version of 100G CWDM4 with RS(528,514) FEC)"},
+	ExtCompliance4wdm20:           {"4WDM-20", "4WDM-20 MSA (20km version of 100GBASE-LR4 with RS(528,514) FEC)"},
+	ExtCompliance4wdm40:           {"4WDM-40", "4WDM-40 MSA (40km reach with APD receiver and RS(528,514) FEC)"},
+	ExtCompliance100gBaseDr:       {"100GBASE-DR", "100GBASE-DR (Clause 140), CAUI-4 (no FEC)"},
+	ExtCompliance100gFr:           {"100G-FR", "100G-FR or 100GBASE-FR1 (Clause 140), CAUI-4 (no FEC)"},
+	ExtCompliance100gLr:           {"100G-LR", "100G-LR or 100GBASE-LR1 (Clause 140), CAUI-4 (no FEC)"},
+	ExtCompliance100gBaseSr1Caui4: {"100GBASE-SR1", "100GBASE-SR1 (Clause 167), CAUI-4 (no FEC)"},
+	ExtCompliance100gBaseSr1:      {"100GBASE-SR1", "100GBASE-SR1, 200GBASE-SR2 or 400GBASE-SR4 (Clause 167)"},
+	ExtCompliance100gBaseFr1:      {"100GBASE-FR1", "100GBASE-FR1 (Clause 140)"},
+	ExtCompliance100gBaseLr1:      {"100GBASE-LR1", "100GBASE-LR1 (Clause 140)"},
+	ExtCompliance100gLr120Caui4:   {"100G-LR1-20", "100G-LR1-20 MSA, CAUI-4 (no FEC)"},
+	ExtCompliance100gEr130Caui4:   {"100G-ER1-30", "100G-ER1-30 MSA, CAUI-4 (no FEC)"},
+	ExtCompliance100gEr140Caui4:   {"100G-ER1-40", "100G-ER1-40 MSA, CAUI-4 (no FEC)"},
+	ExtCompliance100gLr120:        {"100G-LR1-20", "100G-LR1-20 MSA"},
+	ExtComplianceAcc50gaui1e6:     {"50GAUI ACC", "ACC with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M with worst BER of 10^(-6)"},
+	ExtComplianceAoc50gaui1e6:     {"50GAUI AOC", "AOC with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M with worst BER of 10^(-6)"},
+	ExtComplianceAcc50gaui26e4:    {"50GAUI ACC", "ACC with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M with worst BER of 2.6x10^(-4)"},
+	ExtComplianceAoc50gaui26e4:    {"50GAUI AOC", "AOC with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M with worst BER of 2.6x10^(-4)"},
+	ExtCompliance100gEr130:        {"100G-ER1-30", "100G-ER1-30 MSA"},
+	ExtCompliance100gEr140:        {"100G-ER1-40", "100G-ER1-40 MSA"},
+	ExtCompliance100gBaseVr1:      {"100GBASE-VR1", "100GBASE-VR1, 200GBASE-VR2 or 400GBASE-VR4 (Clause 167)"},
+	ExtCompliance10gBaseBr:        {"10GBASE-BR", "10GBASE-BR (Clause 158)"},
+	ExtCompliance25gBaseBr:        {"25GBASE-BR", "25GBASE-BR (Clause 159)"},
+	ExtCompliance50gBaseBr:        {"50GBASE-BR", "50GBASE-BR (Clause 160)"},
+	ExtCompliance100gBaseVr1Caui4: {"100GBASE-VR1", "100GBASE-VR1 (Clause 167), CAUI-4 (no FEC)"},
+	ExtCompliance100gBaseCr1:      {"100GBASE-CR1", "100GBASE-CR1, 200GBASE-CR2 or 400GBASE-CR4 (Clause 162)"},
+	ExtCompliance50gBaseCr:        {"50GBASE-CR", "50GBASE-CR, 100GBASE-CR2 or 200GBASE-CR4"},
+	ExtCompliance50gBaseSr:        {"50GBASE-SR", "50GBASE-SR, 100GBASE-SR2 or 200GBASE-SR4"},
+	ExtCompliance50gBaseFr:        {"50GBASE-FR", "50GBASE-FR or 200GBASE-DR4"},
+	ExtCompliance200gBaseFr4:      {"200GBASE-FR4", "200GBASE-FR4"},
+	ExtCompliance200gPsm4:         {"200G PSM4", "200G 1550 nm PSM4"},
+	ExtCompliance50gBaseLr:        {"50GBASE-LR", "50GBASE-LR"},
+	ExtCompliance200gBaseLr4:      {"200GBASE-LR4", "200GBASE-LR4"},
+	ExtCompliance400gBaseDr4:      {"400GBASE-DR4", "400GBASE-DR4 (Clause 124), 100GAUI-1 C2M (Annex 120G)"},
+	ExtCompliance400gBaseFr4:      {"400GBASE-FR4", "400GBASE-FR4 (Clause 151)"},
+	ExtCompliance400gBaseLr46:     {"400GBASE-LR4-6", "400GBASE-LR4-6 (Clause 151)"},
+	ExtCompliance50gBaseEr:        {"50GBASE-ER", "50GBASE-ER (Clause 139)"},
+	ExtCompliance400gLr410:        {"400G-LR4-10", "400G-LR4-10"},
+	ExtCompliance400gBaseZr:       {"400GBASE-ZR", "400GBASE-ZR (Clause 156)"},
+	ExtCompliance256gfcSw4:        {"256GFC-SW4", "256GFC-SW4 (FC-PI-7P)"},
+	ExtCompliance64gfc:            {"64GFC", "64GFC (FC-PI-7)"},
+	ExtCompliance128gfc:           {"128GFC", "128GFC (FC-PI-8)"},
+}
+
+// ExtendedCompliance is an extended specification compliance code, SFF-8024
+// Table 4-4
+type ExtendedCompliance byte
+
+// Names returns the short and long name of the compliance code
+func (e ExtendedCompliance) Names() Names {
+	return extComplianceNames.lookup(byte(e), 0)
+}
+
+// ShortName returns the short name of the compliance code, e.g.
+// "100GBASE-SR4"
+func (e ExtendedCompliance) ShortName() string {
+	return e.Names().Short
+}
+
+func (e ExtendedCompliance) String() string {
+	return e.Names().Long
+}
+
+// IsSpecified returns true for codes other than Unspecified
+func (e ExtendedCompliance) IsSpecified() bool {
+	return e != ExtComplianceUnspecified
+}
+
+func (e ExtendedCompliance) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": e.String(),
+		"hex":   hex.EncodeToString([]byte{byte(e)}),
+	}
+	return json.Marshal(m)
+}
+
+func (e *ExtendedCompliance) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*e = ExtendedCompliance(b[0])
+	return nil
+}
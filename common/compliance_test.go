@@ -0,0 +1,21 @@
+package common
+
+import "testing"
+
+func TestExtendedCompliance(t *testing.T) {
+	tests := []struct {
+		code ExtendedCompliance
+		want string
+	}{
+		{ExtComplianceUnspecified, "Unspecified"},
+		{ExtCompliance100gBaseSr4, "100GBASE-SR4 or 25GBASE-SR"},
+		{ExtCompliance10gBaseTSfi, "10GBASE-T with SFI electrical interface"},
+		{ExtCompliance400gBaseDr4, "400GBASE-DR4 (Clause 124), 100GAUI-1 C2M (Annex 120G)"},
+		{0x0A, "Reserved or unknown"},
+	}
+	for _, test := range tests {
+		if s := test.code.String(); s != test.want {
+			t.Errorf("ExtendedCompliance(0x%02x).String() = %q, want %q", byte(test.code), s, test.want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 )
 
+// Connector values, SFF-8024 Table 4-3. Values 80h-FFh are vendor specific.
 const (
 	ConnectorUnknown     = 0x00
 	ConnectorSc          = 0x01
@@ -25,38 +26,55 @@ const (
 	ConnectorRj45        = 0x22
 	ConnectorNoSeparable = 0x23
 	ConnectorMxc2x16     = 0x24
+	ConnectorCs          = 0x25
+	ConnectorSn          = 0x26
+	ConnectorMpo2x12     = 0x27
+	ConnectorMpo1x16     = 0x28
+
+	connectorVendorSpecific = 0x80
 )
 
-var connectorNames = map[byte]string{
-	ConnectorUnknown:     "Unknown or unspecified",
-	ConnectorSc:          "SC",
-	ConnectorFcStyle1:    "Fibre Channel style 1 copper",
-	ConnectorFcStyle2:    "Fibre Channel style 2 copper",
-	ConnectorBncTnc:      "BNC/TNC",
-	ConnectorFcCoax:      "Fibre Channel coaxial headers",
-	ConnectorFiberJack:   "FibreJack",
-	ConnectorLc:          "LC",
-	ConnectorMtRj:        "MT-RJ",
-	ConnectorMu:          "MU",
-	ConnectorSg:          "SG",
-	ConnectorOptPtail:    "Optical pigtail",
-	ConnectorMpo:         "MPO Parallel Optic",
-	ConnectorMpo2:        "MPO Parallel Optic - 2x16",
-	ConnectorHssdcII:     "HSSDC II",
-	ConnectorCopperPtail: "Copper pigtail",
-	ConnectorRj45:        "RJ45",
-	ConnectorNoSeparable: "No separable connector",
-	ConnectorMxc2x16:     "MXC 2x16",
+var connectorNames = codeTable{
+	ConnectorUnknown:     {"Unknown", "Unknown or unspecified"},
+	ConnectorSc:          {"SC", "SC"},
+	ConnectorFcStyle1:    {"FC Style 1", "Fibre Channel style 1 copper"},
+	ConnectorFcStyle2:    {"FC Style 2", "Fibre Channel style 2 copper"},
+	ConnectorBncTnc:      {"BNC/TNC", "BNC/TNC"},
+	ConnectorFcCoax:      {"FC coax", "Fibre Channel coaxial headers"},
+	ConnectorFiberJack:   {"FiberJack", "FibreJack"},
+	ConnectorLc:          {"LC", "LC"},
+	ConnectorMtRj:        {"MT-RJ", "MT-RJ"},
+	ConnectorMu:          {"MU", "MU"},
+	ConnectorSg:          {"SG", "SG"},
+	ConnectorOptPtail:    {"Optical pigtail", "Optical pigtail"},
+	ConnectorMpo:         {"MPO 1x12", "MPO Parallel Optic"},
+	ConnectorMpo2:        {"MPO 2x16", "MPO Parallel Optic - 2x16"},
+	ConnectorHssdcII:     {"HSSDC II", "HSSDC II"},
+	ConnectorCopperPtail: {"Copper pigtail", "Copper pigtail"},
+	ConnectorRj45:        {"RJ45", "RJ45"},
+	ConnectorNoSeparable: {"None", "No separable connector"},
+	ConnectorMxc2x16:     {"MXC 2x16", "MXC 2x16"},
+	ConnectorCs:          {"CS", "CS optical connector"},
+	ConnectorSn:          {"SN", "SN (Mini CS) optical connector"},
+	ConnectorMpo2x12:     {"MPO 2x12", "MPO Parallel Optic - 2x12"},
+	ConnectorMpo1x16:     {"MPO 1x16", "MPO Parallel Optic - 1x16"},
 }
 
+// Connector is the media connector type of a module, SFF-8024 Table 4-3
 type Connector byte
 
+// Names returns the short and long name of the connector
+func (c Connector) Names() Names {
+	return connectorNames.lookup(byte(c), connectorVendorSpecific)
+}
+
+// ShortName returns the short name of the connector, e.g. "MPO 1x16"
+func (c Connector) ShortName() string {
+	return c.Names().Short
+}
+
 func (c Connector) String() string {
-	n, ok := connectorNames[byte(c)]
-	if !ok {
-		return "Reserved or unknown"
-	}
-	return n
+	return c.Names().Long
 }
 
 func (c Connector) MarshalJSON() ([]byte, error) {
@@ -0,0 +1,30 @@
+package common
+
+// LaneDiagnostics holds the monitored values of one lane. The power types
+// provide the value in mW and dBm.
+type LaneDiagnostics struct {
+	Lane         int               `json:"lane"` // Lane number, starting at 1
+	TxBias       CurrentMilliAmpBE `json:"txBias"`
+	TxPower      PowerMilliWattBE  `json:"txPower"`
+	RxPower      PowerMilliWattBE  `json:"rxPower"`
+	TxBiasState  AlarmState        `json:"txBiasState"`
+	TxPowerState AlarmState        `json:"txPowerState"`
+	RxPowerState AlarmState        `json:"rxPowerState"`
+}
+
+// Diagnostics is the standard independent view of the digital diagnostic
+// monitors of a module
+type Diagnostics struct {
+	Temperature      TemperatureQ8_8BE `json:"temperature"`
+	Vcc              VoltageVoltBE     `json:"vcc"`
+	TemperatureState AlarmState        `json:"temperatureState"`
+	VccState         AlarmState        `json:"vccState"`
+	Lanes            []LaneDiagnostics `json:"lanes"`
+}
+
+// DiagnosticsProvider is implemented by the decoders of module types with
+// per-lane diagnostic monitors. Diagnostics returns nil if the module does
+// not implement digital diagnostics.
+type DiagnosticsProvider interface {
+	Diagnostics() *Diagnostics
+}
@@ -0,0 +1,131 @@
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Encoding values, SFF-8024 Table 4-2. The meaning of values 04h-06h differs
+// between SFF-8472 (Encoding) and SFF-8636 (EncodingSff8636).
+const (
+	EncodingUnspecified = 0x00
+	Encoding8b10b       = 0x01
+	Encoding4b5b        = 0x02
+	EncodingNrz         = 0x03
+	Encoding4h          = 0x04
+	Encoding5h          = 0x05
+	Encoding6h          = 0x06
+	Encoding256b        = 0x07
+	EncodingPam4        = 0x08
+)
+
+var encodingNames = codeTable{
+	EncodingUnspecified: {"Unspecified", "Unspecified"},
+	Encoding8b10b:       {"8B/10B", "8B/10B"},
+	Encoding4b5b:        {"4B/5B", "4B/5B"},
+	EncodingNrz:         {"NRZ", "NRZ"},
+	Encoding256b:        {"256B/257B", "256B/257B (transcoded FEC-enabled data)"},
+	EncodingPam4:        {"PAM4", "PAM4"},
+}
+
+var (
+	namesManchester = Names{"Manchester", "Manchester"}
+	namesSonet      = Names{"SONET", "SONET Scrambled"}
+	names64b66b     = Names{"64B/66B", "64B/66B"}
+)
+
+var sff8472EncodingNames = encodingNames.with(codeTable{
+	Encoding4h: namesManchester,
+	Encoding5h: namesSonet,
+	Encoding6h: names64b66b,
+})
+
+var sff8636EncodingNames = encodingNames.with(codeTable{
+	Encoding4h: namesSonet,
+	Encoding5h: names64b66b,
+	Encoding6h: namesManchester,
+})
+
+// Encoding is the serial encoding of an SFF-8472 module (byte 11), SFF-8024
+// Table 4-2
+type Encoding byte
+
+// Names returns the short and long name of the encoding
+func (e Encoding) Names() Names {
+	return sff8472EncodingNames.lookup(byte(e), 0)
+}
+
+// ShortName returns the short name of the encoding, e.g. "64B/66B"
+func (e Encoding) ShortName() string {
+	return e.Names().Short
+}
+
+func (e Encoding) String() string {
+	return e.Names().Long
+}
+
+func (e Encoding) MarshalJSON() ([]byte, error) {
+	return encodingToJSON(byte(e), e.String())
+}
+
+func (e *Encoding) UnmarshalJSON(in []byte) error {
+	b, err := encodingFromJSON(in)
+	if err != nil {
+		return err
+	}
+	*e = Encoding(b)
+	return nil
+}
+
+// EncodingSff8636 is the serial encoding of an SFF-8636 module (byte 139),
+// SFF-8024 Table 4-2
+type EncodingSff8636 byte
+
+// Names returns the short and long name of the encoding
+func (e EncodingSff8636) Names() Names {
+	return sff8636EncodingNames.lookup(byte(e), 0)
+}
+
+// ShortName returns the short name of the encoding, e.g. "64B/66B"
+func (e EncodingSff8636) ShortName() string {
+	return e.Names().Short
+}
+
+func (e EncodingSff8636) String() string {
+	return e.Names().Long
+}
+
+func (e EncodingSff8636) MarshalJSON() ([]byte, error) {
+	return encodingToJSON(byte(e), e.String())
+}
+
+func (e *EncodingSff8636) UnmarshalJSON(in []byte) error {
+	b, err := encodingFromJSON(in)
+	if err != nil {
+		return err
+	}
+	*e = EncodingSff8636(b)
+	return nil
+}
+
+func encodingToJSON(b byte, name string) ([]byte, error) {
+	m := map[string]interface{}{
+		"value": name,
+		"hex":   hex.EncodeToString([]byte{b}),
+	}
+	return json.Marshal(m)
+}
+
+func encodingFromJSON(in []byte) (byte, error) {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return 0, err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
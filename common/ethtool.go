@@ -0,0 +1,207 @@
+package common
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// The EthtoolString methods render values with the wording and units of
+// "ethtool -m", so tools parsing ethtool output can read go-sff output.
+
+// EthtoolLine formats a field like "ethtool -m", the label padded to 41
+// columns after a tab
+func EthtoolLine(label, value string) string {
+	return fmt.Sprintf("\t%-41s : %s\n", label, value)
+}
+
+// EthtoolLines formats a field with one line per value, ethtool repeats the
+// label on every line
+func EthtoolLines(label string, values []string) string {
+	var b strings.Builder
+	for _, v := range values {
+		b.WriteString(EthtoolLine(label, v))
+	}
+	return b.String()
+}
+
+// EthtoolASCII formats an ASCII field like ethtool: trailing spaces are
+// trimmed and non-printable characters replaced by '_'
+func EthtoolASCII(b []byte) string {
+	b = []byte(strings.TrimRight(string(b), " "))
+	for i, c := range b {
+		if c < 0x20 || c > 0x7e {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+var ethtoolIdentifierNames = map[byte]string{
+	IdentifierUnknown:    "no module present, unknown, or unspecified",
+	IdentifierGbic:       "GBIC",
+	IdentifierSoldered:   "module soldered to motherboard",
+	IdentifierSfp:        "SFP",
+	Identifier300PinXbi:  "300 pin XBI",
+	IdentifierXenpak:     "XENPAK",
+	IdentifierXfp:        "XFP",
+	IdentifierXff:        "XFF",
+	IdentifierXfpE:       "XFP-E",
+	IdentifierXpak:       "XPAK",
+	IdentifierX2:         "X2",
+	IdentifierDwdmSfp:    "DWDM-SFP",
+	IdentifierQsfp:       "QSFP",
+	IdentifierQsfpPlus:   "QSFP+",
+	IdentifierCxp:        "CXP",
+	IdentifierHd4x:       "Shielded Mini Multilane HD 4X",
+	IdentifierHd8x:       "Shielded Mini Multilane HD 8X",
+	IdentifierQsfp28:     "QSFP28",
+	IdentifierCxp2:       "CXP2/CXP28",
+	IdentifierCdfp:       "CDFP Style 1/Style 2",
+	IdentifierHd4xFanout: "Shielded Mini Multilane HD 4X Fanout Cable",
+	IdentifierHd8xFanout: "Shielded Mini Multilane HD 8X Fanout Cable",
+	IdentifierCdfpStyle3: "CDFP Style 3",
+	IdentifierMicroQsfp:  "microQSFP",
+	IdentifierQsfpDd:     "QSFP-DD Double Density 8X Pluggable Transceiver (INF-8628)",
+	IdentifierOsfp:       "OSFP 8X Pluggable Transceiver",
+	IdentifierDsfp:       "DSFP Dual Small Form Factor Pluggable Transceiver",
+	IdentifierQsfpCmis:   "QSFP+ or later with Common Management Interface Specification (CMIS)",
+	IdentifierSfpDdCmis:  "SFP-DD Double Density 2X Pluggable Transceiver with Common Management Interface Specification (CMIS)",
+	IdentifierSfpCmis:    "SFP+ and later with Common Management Interface Specification (CMIS)",
+}
+
+// EthtoolString returns the identifier like ethtool, e.g. "0x03 (SFP)"
+func (i Identifier) EthtoolString() string {
+	n, ok := ethtoolIdentifierNames[byte(i)]
+	if !ok {
+		n = "reserved or unknown"
+	}
+	return fmt.Sprintf("0x%02x (%s)", byte(i), n)
+}
+
+var ethtoolConnectorNames = map[byte]string{
+	ConnectorUnknown:     "unknown or unspecified",
+	ConnectorSc:          "SC",
+	ConnectorFcStyle1:    "Fibre Channel Style 1 copper",
+	ConnectorFcStyle2:    "Fibre Channel Style 2 copper",
+	ConnectorBncTnc:      "BNC/TNC",
+	ConnectorFcCoax:      "Fibre Channel coaxial headers",
+	ConnectorFiberJack:   "FibreJack",
+	ConnectorLc:          "LC",
+	ConnectorMtRj:        "MT-RJ",
+	ConnectorMu:          "MU",
+	ConnectorSg:          "SG",
+	ConnectorOptPtail:    "Optical pigtail",
+	ConnectorMpo:         "MPO Parallel Optic",
+	ConnectorMpo2:        "MPO Parallel Optic - 2x16",
+	ConnectorHssdcII:     "HSSDC II",
+	ConnectorCopperPtail: "Copper pigtail",
+	0x22:                 "RJ45",
+	0x23:                 "No separable connector",
+	0x24:                 "MXC 2x16",
+	0x25:                 "CS optical connector",
+	0x26:                 "Mini CS optical connector",
+	0x27:                 "MPO 2x12",
+	0x28:                 "MPO 1x16",
+}
+
+// EthtoolString returns the connector like ethtool, e.g. "0x07 (LC)"
+func (c Connector) EthtoolString() string {
+	n, ok := ethtoolConnectorNames[byte(c)]
+	switch {
+	case ok:
+	case byte(c) >= connectorVendorSpecific:
+		n = "vendor specific"
+	default:
+		n = "reserved or unknown"
+	}
+	return fmt.Sprintf("0x%02x (%s)", byte(c), n)
+}
+
+var ethtoolEncodingNames = map[byte]string{
+	EncodingUnspecified: "unspecified",
+	Encoding8b10b:       "8B/10B",
+	Encoding4b5b:        "4B/5B",
+	EncodingNrz:         "NRZ",
+	Encoding256b:        "(256B/257B (transcoded FEC-enabled data)",
+	EncodingPam4:        "PAM4",
+}
+
+// ethtoolEncoding formats an encoding, names holds the meaning of values
+// 04h-06h which differs between SFF-8472 and SFF-8636
+func ethtoolEncoding(e byte, names [3]string) string {
+	n, ok := ethtoolEncodingNames[e]
+	switch {
+	case ok:
+	case e >= Encoding4h && e <= Encoding6h:
+		n = names[e-Encoding4h]
+	default:
+		n = "reserved or unknown"
+	}
+	return fmt.Sprintf("0x%02x (%s)", e, n)
+}
+
+// EthtoolString returns the encoding like ethtool, e.g. "0x06 (64B/66B)"
+func (e Encoding) EthtoolString() string {
+	return ethtoolEncoding(byte(e), [3]string{"Manchester", "SONET Scrambled", "64B/66B"})
+}
+
+// EthtoolString returns the encoding like ethtool, e.g. "0x05 (64B/66B)"
+func (e EncodingSff8636) EthtoolString() string {
+	return ethtoolEncoding(byte(e), [3]string{"SONET Scrambled", "64B/66B", "Manchester"})
+}
+
+// EthtoolString returns the OUI like ethtool, e.g. "00:90:65"
+func (v VendorOUI) EthtoolString() string {
+	return fmt.Sprintf("%02x:%02x:%02x", v[0], v[1], v[2])
+}
+
+// EthtoolString returns the temperature like ethtool, e.g. "34.69 degrees C
+// / 94.44 degrees F"
+func (t TemperatureQ8_8BE) EthtoolString() string {
+	return fmt.Sprintf("%.2f degrees C / %.2f degrees F", t.Celsius(), t.Celsius()*1.8+32)
+}
+
+// EthtoolString returns the voltage like ethtool, e.g. "3.3915 V"
+func (v VoltageVoltBE) EthtoolString() string {
+	return fmt.Sprintf("%.4f V", v.Volts())
+}
+
+// EthtoolString returns the current like ethtool, e.g. "5.786 mA"
+func (c CurrentMilliAmpBE) EthtoolString() string {
+	return fmt.Sprintf("%.3f mA", c.MilliAmp())
+}
+
+// EthtoolString returns the power like ethtool, e.g. "0.7981 mW / -0.98
+// dBm". Zero power is printed as "-inf dBm" like C's printf.
+func (p PowerMilliWattBE) EthtoolString() string {
+	if dBm := p.DBm(); !math.IsInf(dBm, -1) {
+		return fmt.Sprintf("%.4f mW / %.2f dBm", p.MilliWatt(), dBm)
+	}
+	return fmt.Sprintf("%.4f mW / -inf dBm", p.MilliWatt())
+}
+
+type ethtoolStringer interface {
+	EthtoolString() string
+}
+
+// EthtoolThresholds formats the alarm and warning thresholds like ethtool,
+// which prints them in the same order for SFF-8472 and SFF-8636 modules
+func EthtoolThresholds(bias CurrentThresholds, tx PowerThresholds, temp TemperatureThresholds, vcc VoltageThresholds, rx PowerThresholds) string {
+	var b strings.Builder
+	for _, t := range []struct {
+		name   string
+		values [4]ethtoolStringer
+	}{
+		{"Laser bias current", [4]ethtoolStringer{bias.HighAlarm, bias.LowAlarm, bias.HighWarning, bias.LowWarning}},
+		{"Laser output power", [4]ethtoolStringer{tx.HighAlarm, tx.LowAlarm, tx.HighWarning, tx.LowWarning}},
+		{"Module temperature", [4]ethtoolStringer{temp.HighAlarm, temp.LowAlarm, temp.HighWarning, temp.LowWarning}},
+		{"Module voltage", [4]ethtoolStringer{vcc.HighAlarm, vcc.LowAlarm, vcc.HighWarning, vcc.LowWarning}},
+		{"Laser rx power", [4]ethtoolStringer{rx.HighAlarm, rx.LowAlarm, rx.HighWarning, rx.LowWarning}},
+	} {
+		for i, level := range []string{"high alarm", "low alarm", "high warning", "low warning"} {
+			b.WriteString(EthtoolLine(t.name+" "+level+" threshold", t.values[i].EthtoolString()))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,31 @@
+package common
+
+import "testing"
+
+func TestEthtoolString(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"identifier", Identifier(IdentifierSfp).EthtoolString(), "0x03 (SFP)"},
+		{"identifier reserved", Identifier(0x7f).EthtoolString(), "0x7f (reserved or unknown)"},
+		{"connector", Connector(ConnectorLc).EthtoolString(), "0x07 (LC)"},
+		{"connector vendor", Connector(0x80).EthtoolString(), "0x80 (vendor specific)"},
+		{"encoding SFF-8472", Encoding(Encoding6h).EthtoolString(), "0x06 (64B/66B)"},
+		{"encoding SFF-8636", EncodingSff8636(Encoding5h).EthtoolString(), "0x05 (64B/66B)"},
+		{"oui", VendorOUI{0x00, 0x90, 0x65}.EthtoolString(), "00:90:65"},
+		{"temperature", TemperatureQ8_8BE{0xf6, 0x00}.EthtoolString(), "-10.00 degrees C / 14.00 degrees F"},
+		{"voltage", VoltageVoltBE{0x82, 0x9b}.EthtoolString(), "3.3435 V"},
+		{"current", CurrentMilliAmpBE{0x0a, 0xd2}.EthtoolString(), "5.540 mA"},
+		{"power", PowerMilliWattBE{0x27, 0x10}.EthtoolString(), "1.0000 mW / 0.00 dBm"},
+		{"power zero", PowerMilliWattBE{}.EthtoolString(), "0.0000 mW / -inf dBm"},
+		{"ascii", EthtoolASCII([]byte("AB\x01C    ")), "AB_C"},
+		{"line", EthtoolLine("Vendor PN", "P.8596.02"), "\tVendor PN                                 : P.8596.02\n"},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, test.got, test.want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 )
 
+// Identifier values, SFF-8024 Table 4-1. Values 80h-FFh are vendor specific.
 const (
 	IdentifierUnknown    = 0x00
 	IdentifierGbic       = 0x01
@@ -30,43 +31,72 @@ const (
 	IdentifierHd8xFanout = 0x15
 	IdentifierCdfpStyle3 = 0x16
 	IdentifierMicroQsfp  = 0x17
+	IdentifierQsfpDd     = 0x18
+	IdentifierOsfp       = 0x19
+	IdentifierSfpDd      = 0x1A
+	IdentifierDsfp       = 0x1B
+	IdentifierMiniLink4x = 0x1C
+	IdentifierMiniLink8x = 0x1D
+	IdentifierQsfpCmis   = 0x1E
+	IdentifierSfpDdCmis  = 0x1F
+	IdentifierSfpCmis    = 0x20
+	IdentifierOsfpXd     = 0x21
+
+	identifierVendorSpecific = 0x80
 )
 
-var identifierNames = map[byte]string{
-	IdentifierUnknown:    "No module present, unknown, or unspecified",
-	IdentifierGbic:       "GBIC",
-	IdentifierSoldered:   "Module soldered to motherboard",
-	IdentifierSfp:        "SFP",
-	Identifier300PinXbi:  "300 pin XBI",
-	IdentifierXenpak:     "XENPAK",
-	IdentifierXfp:        "XFP",
-	IdentifierXff:        "XFF",
-	IdentifierXfpE:       "XFP-E",
-	IdentifierXpak:       "XPAK",
-	IdentifierX2:         "X2",
-	IdentifierDwdmSfp:    "DWDM-SFP",
-	IdentifierQsfp:       "QSFP",
-	IdentifierQsfpPlus:   "QSFP+",
-	IdentifierCxp:        "CXP",
-	IdentifierHd4x:       "Shielded Mini Multilane HD 4X",
-	IdentifierHd8x:       "Shielded Mini Multilane HD 8X",
-	IdentifierQsfp28:     "QSFP28",
-	IdentifierCxp2:       "CXP2/CXP28",
-	IdentifierCdfp:       "CDFP Style 1/Style 2",
-	IdentifierHd4xFanout: "Shielded Mini Multilane HD 4X Fanout Cable",
-	IdentifierHd8xFanout: "Shielded Mini Multilane HD 8X Fanout Cable",
-	IdentifierCdfpStyle3: "CDFP Style 3",
-	IdentifierMicroQsfp:  "MicroQSFP",
+var identifierNames = codeTable{
+	IdentifierUnknown:    {"Unknown", "No module present, unknown, or unspecified"},
+	IdentifierGbic:       {"GBIC", "GBIC"},
+	IdentifierSoldered:   {"Soldered", "Module soldered to motherboard"},
+	IdentifierSfp:        {"SFP", "SFP/SFP+/SFP28"},
+	Identifier300PinXbi:  {"XBI", "300 pin XBI"},
+	IdentifierXenpak:     {"XENPAK", "XENPAK"},
+	IdentifierXfp:        {"XFP", "XFP"},
+	IdentifierXff:        {"XFF", "XFF"},
+	IdentifierXfpE:       {"XFP-E", "XFP-E"},
+	IdentifierXpak:       {"XPAK", "XPAK"},
+	IdentifierX2:         {"X2", "X2"},
+	IdentifierDwdmSfp:    {"DWDM-SFP", "DWDM-SFP/SFP+ (not using SFF-8472)"},
+	IdentifierQsfp:       {"QSFP", "QSFP (INF-8438)"},
+	IdentifierQsfpPlus:   {"QSFP+", "QSFP+ or later with SFF-8636 or SFF-8436"},
+	IdentifierCxp:        {"CXP", "CXP or later"},
+	IdentifierHd4x:       {"HD4X", "Shielded Mini Multilane HD 4X"},
+	IdentifierHd8x:       {"HD8X", "Shielded Mini Multilane HD 8X"},
+	IdentifierQsfp28:     {"QSFP28", "QSFP28 or later with SFF-8636"},
+	IdentifierCxp2:       {"CXP2", "CXP2/CXP28"},
+	IdentifierCdfp:       {"CDFP", "CDFP Style 1/Style 2"},
+	IdentifierHd4xFanout: {"HD4X Fanout", "Shielded Mini Multilane HD 4X Fanout Cable"},
+	IdentifierHd8xFanout: {"HD8X Fanout", "Shielded Mini Multilane HD 8X Fanout Cable"},
+	IdentifierCdfpStyle3: {"CDFP-S3", "CDFP Style 3"},
+	IdentifierMicroQsfp:  {"microQSFP", "MicroQSFP"},
+	IdentifierQsfpDd:     {"QSFP-DD", "QSFP-DD Double Density 8X Pluggable Transceiver"},
+	IdentifierOsfp:       {"OSFP", "OSFP 8X Pluggable Transceiver"},
+	IdentifierSfpDd:      {"SFP-DD", "SFP-DD Double Density 2X Pluggable Transceiver"},
+	IdentifierDsfp:       {"DSFP", "DSFP Dual Small Form Factor Pluggable Transceiver"},
+	IdentifierMiniLink4x: {"MiniLink4x", "x4 MiniLink/OcuLink"},
+	IdentifierMiniLink8x: {"MiniLink8x", "x8 MiniLink"},
+	IdentifierQsfpCmis:   {"QSFP-CMIS", "QSFP+ or later with CMIS"},
+	IdentifierSfpDdCmis:  {"SFP-DD-CMIS", "SFP-DD Double Density 2X Pluggable Transceiver with CMIS"},
+	IdentifierSfpCmis:    {"SFP-CMIS", "SFP+ and later with CMIS"},
+	IdentifierOsfpXd:     {"OSFP-XD", "OSFP-XD with CMIS"},
 }
 
+// Identifier is the type of a module, SFF-8024 Table 4-1
 type Identifier byte
 
+// Names returns the short and long name of the identifier
+func (i Identifier) Names() Names {
+	return identifierNames.lookup(byte(i), identifierVendorSpecific)
+}
+
+// ShortName returns the short name of the identifier, e.g. "QSFP-DD"
+func (i Identifier) ShortName() string {
+	return i.Names().Short
+}
+
 func (i Identifier) String() string {
-	s, ok := identifierNames[byte(i)]
-	if !ok {
-		return "Reserved or unknown"
-	}
-	return s
+	return i.Names().Long
 }
 
 func (i Identifier) MarshalJSON() ([]byte, error) {
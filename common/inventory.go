@@ -0,0 +1,35 @@
+package common
+
+// Reach holds the supported link length per fiber type in meters, 0 if the
+// module does not specify a length for the fiber type
+type Reach struct {
+	Smf    float64 `json:"smf"`
+	Om1    float64 `json:"om1"`
+	Om2    float64 `json:"om2"`
+	Om3    float64 `json:"om3"`
+	Om4    float64 `json:"om4"`
+	Om5    float64 `json:"om5"`
+	Copper float64 `json:"copper"` // Length of copper and active cable assemblies
+}
+
+// Inventory is the vendor independent identification of a module
+type Inventory struct {
+	Identifier   Identifier `json:"identifier"`
+	Vendor       string     `json:"vendor"`
+	OUI          VendorOUI  `json:"oui"`
+	PartNumber   string     `json:"partNumber"`
+	Revision     string     `json:"revision"`
+	SerialNumber string     `json:"serialNumber"`
+	DateCode     DateCode   `json:"dateCode"`
+	Connector    Connector  `json:"connector"`
+	BitRate      int        `json:"bitRate"`    // Nominal bit rate per lane in Mb/s, 0 if not specified
+	Wavelength   float64    `json:"wavelength"` // Nominal wavelength in nm, 0 for copper
+	LaneCount    int        `json:"laneCount"`  // Number of media lanes
+	MediaType    MediaType  `json:"mediaType"`
+	Reach        Reach      `json:"reach"`
+}
+
+// InventoryProvider is implemented by the decoders of all module types
+type InventoryProvider interface {
+	Inventory() Inventory
+}
@@ -0,0 +1,327 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// I2C device addresses of module memory
+const (
+	AddressA0 = 0x50 // A0h, SFP serial ID and QSFP/CMIS memory
+	AddressA2 = 0x51 // A2h, SFF-8472 diagnostics
+)
+
+// PageLen is the size of a lower or upper half page
+const PageLen = 128
+
+var ErrRegionNotPresent = errors.New("region not present")
+
+// Region identifies a window of module memory. Offsets 0-127 address the lower
+// page which is the same regardless of Bank and Page, offsets 128-255 address
+// the upper page selected by Bank and Page.
+type Region struct {
+	Address uint8 `json:"address"` // I2C device address
+	Bank    uint8 `json:"bank"`    // Bank of the upper page (CMIS)
+	Page    uint8 `json:"page"`    // Upper page
+	Offset  int   `json:"offset"`  // Byte offset 0-255
+	Length  int   `json:"length"`  // Number of bytes
+}
+
+// LowerPage returns the region covering the lower page of address
+func LowerPage(address uint8) Region {
+	return Region{Address: address, Offset: 0, Length: PageLen}
+}
+
+// UpperPage returns the region covering the given upper page of address
+func UpperPage(address, bank, page uint8) Region {
+	return Region{Address: address, Bank: bank, Page: page, Offset: PageLen, Length: PageLen}
+}
+
+// IsUpper returns true if the region starts in the upper page
+func (r Region) IsUpper() bool {
+	return r.Offset >= PageLen
+}
+
+// Validate checks that the region is within the 256 byte address space
+func (r Region) Validate() error {
+	if r.Offset < 0 || r.Length < 0 || r.Offset+r.Length > 2*PageLen {
+		return fmt.Errorf("invalid region %s", r)
+	}
+	return nil
+}
+
+// Split splits the region at the half page boundary. The lower part has
+// Bank and Page cleared as they do not apply to the lower page.
+func (r Region) Split() []Region {
+	if r.Offset >= PageLen {
+		return []Region{r}
+	}
+	if r.Offset+r.Length <= PageLen {
+		return []Region{{Address: r.Address, Offset: r.Offset, Length: r.Length}}
+	}
+	return []Region{
+		{Address: r.Address, Offset: r.Offset, Length: PageLen - r.Offset},
+		{Address: r.Address, Bank: r.Bank, Page: r.Page, Offset: PageLen, Length: r.Offset + r.Length - PageLen},
+	}
+}
+
+func (r Region) String() string {
+	if r.IsUpper() {
+		return fmt.Sprintf("%02Xh bank %d page %02Xh [%d-%d]", r.Address<<1, r.Bank, r.Page, r.Offset, r.Offset+r.Length-1)
+	}
+	return fmt.Sprintf("%02Xh [%d-%d]", r.Address<<1, r.Offset, r.Offset+r.Length-1)
+}
+
+type pageKey struct {
+	address uint8
+	bank    uint8
+	page    uint8
+	upper   bool
+}
+
+// Memory is a page-aware image of module memory. Half pages become present
+// when they are first written, bytes not written read as zero.
+type Memory struct {
+	pages map[pageKey]*[PageLen]byte
+}
+
+// NewMemory creates an empty Memory
+func NewMemory() *Memory {
+	return &Memory{pages: map[pageKey]*[PageLen]byte{}}
+}
+
+func (r Region) key() pageKey {
+	if !r.IsUpper() {
+		return pageKey{address: r.Address}
+	}
+	return pageKey{address: r.Address, bank: r.Bank, page: r.Page, upper: true}
+}
+
+// Write stores b at region r. len(b) must equal r.Length.
+func (m *Memory) Write(r Region, b []byte) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	if len(b) != r.Length {
+		return fmt.Errorf("region %s needs %d bytes, got %d", r, r.Length, len(b))
+	}
+	for _, p := range r.Split() {
+		page, ok := m.pages[p.key()]
+		if !ok {
+			page = &[PageLen]byte{}
+			m.pages[p.key()] = page
+		}
+		copy(page[p.Offset%PageLen:], b[:p.Length])
+		b = b[p.Length:]
+	}
+	return nil
+}
+
+// Read returns a copy of region r. It returns an error wrapping
+// ErrRegionNotPresent if any part of r has not been written.
+func (m *Memory) Read(r Region) ([]byte, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	b := make([]byte, 0, r.Length)
+	for _, p := range r.Split() {
+		page, ok := m.pages[p.key()]
+		if !ok {
+			return nil, fmt.Errorf("%s: %w", p, ErrRegionNotPresent)
+		}
+		b = append(b, page[p.Offset%PageLen:p.Offset%PageLen+p.Length]...)
+	}
+	return b, nil
+}
+
+// Has returns true if all of region r is present
+func (m *Memory) Has(r Region) bool {
+	if r.Validate() != nil {
+		return false
+	}
+	for _, p := range r.Split() {
+		if _, ok := m.pages[p.key()]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Regions returns the present half pages ordered by address, bank and page
+func (m *Memory) Regions() []Region {
+	r := []Region{}
+	for k := range m.pages {
+		if k.upper {
+			r = append(r, UpperPage(k.address, k.bank, k.page))
+		} else {
+			r = append(r, LowerPage(k.address))
+		}
+	}
+	sort.Slice(r, func(i, j int) bool {
+		a, b := r[i], r[j]
+		if a.Address != b.Address {
+			return a.Address < b.Address
+		}
+		if a.Offset != b.Offset {
+			return a.Offset < b.Offset
+		}
+		if a.Bank != b.Bank {
+			return a.Bank < b.Bank
+		}
+		return a.Page < b.Page
+	})
+	return r
+}
+
+// read returns region r, or zeros for the parts not present
+func (m *Memory) read(r Region) []byte {
+	b := make([]byte, 0, r.Length)
+	for _, p := range r.Split() {
+		if page, ok := m.pages[p.key()]; ok {
+			b = append(b, page[p.Offset%PageLen:p.Offset%PageLen+p.Length]...)
+		} else {
+			b = append(b, make([]byte, p.Length)...)
+		}
+	}
+	return b
+}
+
+// writeFlat stores b into consecutive regions, as far as b reaches
+func (m *Memory) writeFlat(regions []Region, b []byte) {
+	for _, r := range regions {
+		if len(b) == 0 {
+			return
+		}
+		n := r.Length
+		if n > len(b) {
+			n = len(b)
+		}
+		r.Length = n
+		m.Write(r, b[:n])
+		b = b[n:]
+	}
+}
+
+// Flat SFF-8079/SFF-8472 layout: A0h followed by A2h (page 00h)
+var flatSff8079 = []Region{
+	LowerPage(AddressA0),
+	UpperPage(AddressA0, 0, 0),
+	LowerPage(AddressA2),
+	UpperPage(AddressA2, 0, 0),
+}
+
+// Flat SFF-8636 layout as used by ethtool: lower page followed by upper
+// pages 00h-03h
+var flatSff8636 = []Region{
+	LowerPage(AddressA0),
+	UpperPage(AddressA0, 0, 0),
+	UpperPage(AddressA0, 0, 1),
+	UpperPage(AddressA0, 0, 2),
+	UpperPage(AddressA0, 0, 3),
+}
+
+// Flat CMIS layout: the SFF-8636 layout extended with bank 0 pages 10h and
+// 11h, so dumps of the lower page and pages 00h-03h decode the same way
+var flatCmis = []Region{
+	LowerPage(AddressA0),
+	UpperPage(AddressA0, 0, 0),
+	UpperPage(AddressA0, 0, 1),
+	UpperPage(AddressA0, 0, 2),
+	UpperPage(AddressA0, 0, 3),
+	UpperPage(AddressA0, 0, 0x10),
+	UpperPage(AddressA0, 0, 0x11),
+}
+
+// parseFlat copies b into consecutive regions of layout. Unlike writeFlat it
+// fails if b ends within a region, so every region present is complete.
+func parseFlat(layout []Region, b []byte) (*Memory, error) {
+	m := NewMemory()
+	for _, r := range layout {
+		if len(b) == 0 {
+			break
+		}
+		if len(b) < r.Length {
+			return nil, fmt.Errorf("%s truncated, got %d of %d bytes", r, len(b), r.Length)
+		}
+		if err := m.Write(r, b[:r.Length]); err != nil {
+			return nil, err
+		}
+		b = b[r.Length:]
+	}
+	return m, nil
+}
+
+// ParseFlatSff8079 copies a flat SFP dump into Memory like
+// MemoryFromFlatSff8079, but returns an error if the dump ends within a half
+// page. The regions present are reported by Regions.
+func ParseFlatSff8079(eeprom []byte) (*Memory, error) {
+	return parseFlat(flatSff8079, eeprom)
+}
+
+// ParseFlatSff8636 is ParseFlatSff8079 for flat QSFP dumps, see
+// MemoryFromFlatSff8636
+func ParseFlatSff8636(eeprom []byte) (*Memory, error) {
+	return parseFlat(flatSff8636, eeprom)
+}
+
+// ParseFlatCmis is ParseFlatSff8079 for flat CMIS dumps, see
+// MemoryFromFlatCmis
+func ParseFlatCmis(eeprom []byte) (*Memory, error) {
+	return parseFlat(flatCmis, eeprom)
+}
+
+// MemoryFromFlatSff8079 creates Memory from a flat SFP dump with A0h at
+// bytes 0-255 and A2h at bytes 256-511
+func MemoryFromFlatSff8079(eeprom []byte) *Memory {
+	m := NewMemory()
+	m.writeFlat(flatSff8079, eeprom)
+	return m
+}
+
+// MemoryFromFlatSff8636 creates Memory from a flat QSFP dump with the lower
+// page at bytes 0-127 and upper page N at bytes 128+N*128
+func MemoryFromFlatSff8636(eeprom []byte) *Memory {
+	m := NewMemory()
+	m.writeFlat(flatSff8636, eeprom)
+	return m
+}
+
+// MemoryFromFlatCmis creates Memory from a flat CMIS dump with the lower
+// page at bytes 0-127, upper pages 00h-03h at bytes 128-639 and bank 0 pages
+// 10h and 11h at bytes 640-895
+func MemoryFromFlatCmis(eeprom []byte) *Memory {
+	m := NewMemory()
+	m.writeFlat(flatCmis, eeprom)
+	return m
+}
+
+// FlatSff8079 returns the 512 byte flat SFP layout, with zeros for regions
+// not present
+func (m *Memory) FlatSff8079() []byte {
+	b := []byte{}
+	for _, r := range flatSff8079 {
+		b = append(b, m.read(r)...)
+	}
+	return b
+}
+
+// FlatSff8636 returns the 640 byte flat QSFP layout, with zeros for regions
+// not present
+func (m *Memory) FlatSff8636() []byte {
+	b := []byte{}
+	for _, r := range flatSff8636 {
+		b = append(b, m.read(r)...)
+	}
+	return b
+}
+
+// FlatCmis returns the 896 byte flat CMIS layout, with zeros for regions not
+// present
+func (m *Memory) FlatCmis() []byte {
+	b := []byte{}
+	for _, r := range flatCmis {
+		b = append(b, m.read(r)...)
+	}
+	return b
+}
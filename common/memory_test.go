@@ -0,0 +1,142 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRegionSplit(t *testing.T) {
+	r := Region{Address: AddressA0, Bank: 1, Page: 3, Offset: 120, Length: 16}
+	parts := r.Split()
+	if len(parts) != 2 {
+		t.Fatalf("Split() returned %d regions, want 2", len(parts))
+	}
+	if parts[0] != (Region{Address: AddressA0, Offset: 120, Length: 8}) {
+		t.Errorf("Unexpected lower part %+v", parts[0])
+	}
+	if parts[1] != (Region{Address: AddressA0, Bank: 1, Page: 3, Offset: 128, Length: 8}) {
+		t.Errorf("Unexpected upper part %+v", parts[1])
+	}
+
+	if err := (Region{Offset: 200, Length: 57}).Validate(); err == nil {
+		t.Error("Validate() should fail for region beyond 256 bytes")
+	}
+}
+
+func TestMemoryReadWrite(t *testing.T) {
+	m := NewMemory()
+	if err := m.Write(UpperPage(AddressA0, 0, 3), bytes.Repeat([]byte{3}, PageLen)); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := m.Write(LowerPage(AddressA0), bytes.Repeat([]byte{1}, PageLen)); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	b, err := m.Read(Region{Address: AddressA0, Page: 3, Offset: 126, Length: 4})
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if !bytes.Equal(b, []byte{1, 1, 3, 3}) {
+		t.Errorf("Read() = % x, want 01 01 03 03", b)
+	}
+
+	// Upper page 00h has not been written
+	if _, err := m.Read(UpperPage(AddressA0, 0, 0)); !errors.Is(err, ErrRegionNotPresent) {
+		t.Errorf("Expected ErrRegionNotPresent, got %v", err)
+	}
+	if m.Has(UpperPage(AddressA0, 0, 0)) {
+		t.Error("Has() should be false for upper page 00h")
+	}
+
+	regions := m.Regions()
+	if len(regions) != 2 || regions[0] != LowerPage(AddressA0) || regions[1] != UpperPage(AddressA0, 0, 3) {
+		t.Errorf("Unexpected regions %v", regions)
+	}
+}
+
+func TestMemoryFlat(t *testing.T) {
+	flat := make([]byte, 512)
+	for i := range flat {
+		flat[i] = byte(i / PageLen)
+	}
+
+	m := MemoryFromFlatSff8079(flat)
+	if b, _ := m.Read(LowerPage(AddressA2)); b[0] != 2 {
+		t.Errorf("A2h lower page should start at byte 256")
+	}
+	if !bytes.Equal(m.FlatSff8079(), flat) {
+		t.Error("FlatSff8079() does not match input")
+	}
+
+	m = MemoryFromFlatSff8636(flat[:384])
+	if b, _ := m.Read(UpperPage(AddressA0, 0, 1)); b[0] != 2 {
+		t.Errorf("Upper page 01h should start at byte 256")
+	}
+	if m.Has(UpperPage(AddressA0, 0, 2)) {
+		t.Error("Upper page 02h should not be present in a 384 byte dump")
+	}
+	b := m.FlatSff8636()
+	if len(b) != 640 || !bytes.Equal(b[:384], flat[:384]) || b[384] != 0 {
+		t.Error("FlatSff8636() does not match input")
+	}
+}
+
+func TestMemoryFlatCmis(t *testing.T) {
+	flat := make([]byte, 896)
+	for i := range flat {
+		flat[i] = byte(i / PageLen)
+	}
+
+	m := MemoryFromFlatCmis(flat)
+	if b, _ := m.Read(UpperPage(AddressA0, 0, 0x10)); b[0] != 5 {
+		t.Errorf("Upper page 10h should start at byte 640")
+	}
+	if b, _ := m.Read(UpperPage(AddressA0, 0, 0x11)); b[0] != 6 {
+		t.Errorf("Upper page 11h should start at byte 768")
+	}
+	if !bytes.Equal(m.FlatCmis(), flat) {
+		t.Error("FlatCmis() does not match input")
+	}
+
+	// ethtool SFF-8636 sized dumps only cover pages up to 03h
+	m = MemoryFromFlatCmis(flat[:640])
+	if m.Has(UpperPage(AddressA0, 0, 0x10)) {
+		t.Error("Upper page 10h should not be present in a 640 byte dump")
+	}
+}
+
+func TestParseFlat(t *testing.T) {
+	flat := make([]byte, 512)
+	for i := range flat {
+		flat[i] = byte(i / PageLen)
+	}
+
+	m, err := ParseFlatSff8079(flat[:384])
+	if err != nil {
+		t.Fatalf("ParseFlatSff8079 failed: %v", err)
+	}
+	want := []Region{LowerPage(AddressA0), UpperPage(AddressA0, 0, 0), LowerPage(AddressA2)}
+	if got := m.Regions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Regions() = %v, want %v", got, want)
+	}
+
+	// The memory does not alias the input
+	flat[0] = 0xff
+	if b, _ := m.Read(LowerPage(AddressA0)); b[0] != 0 {
+		t.Error("Memory changed with the input buffer")
+	}
+
+	for _, n := range []int{1, 200, 300} {
+		if _, err := ParseFlatSff8079(flat[:n]); err == nil {
+			t.Errorf("Expected error for %d byte SFP dump", n)
+		}
+	}
+	if _, err := ParseFlatSff8636(flat[:500]); err == nil {
+		t.Error("Expected error for 500 byte QSFP dump")
+	}
+	if _, err := ParseFlatCmis(make([]byte, 700)); err == nil {
+		t.Error("Expected error for 700 byte CMIS dump")
+	}
+}
@@ -0,0 +1,42 @@
+package common
+
+// Names holds the short and long name of an SFF-8024 code. The short name is
+// suitable for tables and metric labels, the long name follows the
+// description in SFF-8024.
+type Names struct {
+	Short string `json:"short"`
+	Long  string `json:"long"`
+}
+
+// codeTable maps the codes of an SFF-8024 table to their names
+type codeTable map[byte]Names
+
+var (
+	namesReserved       = Names{"Reserved", "Reserved or unknown"}
+	namesVendorSpecific = Names{"Vendor specific", "Vendor specific"}
+)
+
+// lookup returns the names of code b. Codes from vendor and up that are not
+// in the table are vendor specific, a vendor of 0 means the table has no
+// vendor specific range.
+func (t codeTable) lookup(b byte, vendor byte) Names {
+	if n, ok := t[b]; ok {
+		return n
+	}
+	if vendor != 0 && b >= vendor {
+		return namesVendorSpecific
+	}
+	return namesReserved
+}
+
+// with returns a copy of the table with the entries of o added
+func (t codeTable) with(o codeTable) codeTable {
+	r := make(codeTable, len(t)+len(o))
+	for k, v := range t {
+		r[k] = v
+	}
+	for k, v := range o {
+		r[k] = v
+	}
+	return r
+}
@@ -0,0 +1,33 @@
+package common
+
+import "testing"
+
+func TestCodeNames(t *testing.T) {
+	tests := []struct {
+		code  interface{ Names() Names }
+		short string
+		long  string
+	}{
+		{Identifier(IdentifierQsfpDd), "QSFP-DD", "QSFP-DD Double Density 8X Pluggable Transceiver"},
+		{Identifier(IdentifierDsfp), "DSFP", "DSFP Dual Small Form Factor Pluggable Transceiver"},
+		{Identifier(0x7F), "Reserved", "Reserved or unknown"},
+		{Identifier(0x80), "Vendor specific", "Vendor specific"},
+		{Connector(ConnectorSn), "SN", "SN (Mini CS) optical connector"},
+		{Connector(ConnectorMpo1x16), "MPO 1x16", "MPO Parallel Optic - 1x16"},
+		{Connector(0x29), "Reserved", "Reserved or unknown"},
+		{Connector(0xFF), "Vendor specific", "Vendor specific"},
+		{ExtendedCompliance(ExtCompliance400gBaseDr4), "400GBASE-DR4", "400GBASE-DR4 (Clause 124), 100GAUI-1 C2M (Annex 120G)"},
+		{ExtendedCompliance(0xFF), "Reserved", "Reserved or unknown"},
+		{Encoding(EncodingPam4), "PAM4", "PAM4"},
+		{Encoding(Encoding4h), "Manchester", "Manchester"},
+		{EncodingSff8636(Encoding4h), "SONET", "SONET Scrambled"},
+		{EncodingSff8636(Encoding6h), "Manchester", "Manchester"},
+		{Encoding(0x09), "Reserved", "Reserved or unknown"},
+	}
+	for _, test := range tests {
+		n := test.code.Names()
+		if n.Short != test.short || n.Long != test.long {
+			t.Errorf("%T.Names() = %q/%q, want %q/%q", test.code, n.Short, n.Long, test.short, test.long)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AlarmState is the state of a monitored value relative to its thresholds
+type AlarmState int
+
+const (
+	AlarmStateNormal AlarmState = iota
+	AlarmStateLowWarning
+	AlarmStateHighWarning
+	AlarmStateLowAlarm
+	AlarmStateHighAlarm
+	AlarmStateNone // No thresholds to compare against
+)
+
+var alarmStateNames = map[AlarmState]string{
+	AlarmStateNormal:      "Normal",
+	AlarmStateLowWarning:  "Low warning",
+	AlarmStateHighWarning: "High warning",
+	AlarmStateLowAlarm:    "Low alarm",
+	AlarmStateHighAlarm:   "High alarm",
+	AlarmStateNone:        "No thresholds",
+}
+
+func (a AlarmState) String() string {
+	n, ok := alarmStateNames[a]
+	if !ok {
+		return "Unknown"
+	}
+	return n
+}
+
+// IsAlarm returns true for the high and low alarm states
+func (a AlarmState) IsAlarm() bool {
+	return a == AlarmStateLowAlarm || a == AlarmStateHighAlarm
+}
+
+// IsWarning returns true for the high and low warning states
+func (a AlarmState) IsWarning() bool {
+	return a == AlarmStateLowWarning || a == AlarmStateHighWarning
+}
+
+func (a AlarmState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+func (a *AlarmState) UnmarshalJSON(in []byte) error {
+	var s string
+	if err := json.Unmarshal(in, &s); err != nil {
+		return err
+	}
+	for k, v := range alarmStateNames {
+		if v == s {
+			*a = k
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown alarm state %q", s)
+}
+
+// alarmState compares v against the thresholds. Modules that do not provide
+// thresholds report all of them as zero, in which case AlarmStateNone is
+// returned.
+func alarmState(v, highAlarm, lowAlarm, highWarning, lowWarning float64) AlarmState {
+	switch {
+	case highAlarm == 0 && lowAlarm == 0 && highWarning == 0 && lowWarning == 0:
+		return AlarmStateNone
+	case v > highAlarm:
+		return AlarmStateHighAlarm
+	case v < lowAlarm:
+		return AlarmStateLowAlarm
+	case v > highWarning:
+		return AlarmStateHighWarning
+	case v < lowWarning:
+		return AlarmStateLowWarning
+	}
+	return AlarmStateNormal
+}
+
+// TemperatureThresholds holds the alarm and warning thresholds of a
+// temperature monitor, in the order used by SFF-8472 and SFF-8636
+type TemperatureThresholds struct {
+	HighAlarm   TemperatureQ8_8BE `json:"highAlarm"`
+	LowAlarm    TemperatureQ8_8BE `json:"lowAlarm"`
+	HighWarning TemperatureQ8_8BE `json:"highWarning"`
+	LowWarning  TemperatureQ8_8BE `json:"lowWarning"`
+}
+
+// State returns the alarm state of v relative to the thresholds
+func (t TemperatureThresholds) State(v TemperatureQ8_8BE) AlarmState {
+	return alarmState(v.Celsius(), t.HighAlarm.Celsius(), t.LowAlarm.Celsius(), t.HighWarning.Celsius(), t.LowWarning.Celsius())
+}
+
+// VoltageThresholds holds the alarm and warning thresholds of a supply
+// voltage monitor, in the order used by SFF-8472 and SFF-8636
+type VoltageThresholds struct {
+	HighAlarm   VoltageVoltBE `json:"highAlarm"`
+	LowAlarm    VoltageVoltBE `json:"lowAlarm"`
+	HighWarning VoltageVoltBE `json:"highWarning"`
+	LowWarning  VoltageVoltBE `json:"lowWarning"`
+}
+
+// State returns the alarm state of v relative to the thresholds
+func (t VoltageThresholds) State(v VoltageVoltBE) AlarmState {
+	return alarmState(v.Volts(), t.HighAlarm.Volts(), t.LowAlarm.Volts(), t.HighWarning.Volts(), t.LowWarning.Volts())
+}
+
+// CurrentThresholds holds the alarm and warning thresholds of a Tx bias
+// current monitor, in the order used by SFF-8472 and SFF-8636
+type CurrentThresholds struct {
+	HighAlarm   CurrentMilliAmpBE `json:"highAlarm"`
+	LowAlarm    CurrentMilliAmpBE `json:"lowAlarm"`
+	HighWarning CurrentMilliAmpBE `json:"highWarning"`
+	LowWarning  CurrentMilliAmpBE `json:"lowWarning"`
+}
+
+// State returns the alarm state of v relative to the thresholds
+func (t CurrentThresholds) State(v CurrentMilliAmpBE) AlarmState {
+	return alarmState(v.MilliAmp(), t.HighAlarm.MilliAmp(), t.LowAlarm.MilliAmp(), t.HighWarning.MilliAmp(), t.LowWarning.MilliAmp())
+}
+
+// PowerThresholds holds the alarm and warning thresholds of an optical power
+// monitor, in the order used by SFF-8472 and SFF-8636
+type PowerThresholds struct {
+	HighAlarm   PowerMilliWattBE `json:"highAlarm"`
+	LowAlarm    PowerMilliWattBE `json:"lowAlarm"`
+	HighWarning PowerMilliWattBE `json:"highWarning"`
+	LowWarning  PowerMilliWattBE `json:"lowWarning"`
+}
+
+// State returns the alarm state of v relative to the thresholds
+func (t PowerThresholds) State(v PowerMilliWattBE) AlarmState {
+	return alarmState(v.MilliWatt(), t.HighAlarm.MilliWatt(), t.LowAlarm.MilliWatt(), t.HighWarning.MilliWatt(), t.LowWarning.MilliWatt())
+}
@@ -0,0 +1,58 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPowerThresholdsState(t *testing.T) {
+	th := PowerThresholds{
+		HighAlarm:   PowerMilliWattBE{0x27, 0x10}, // 1.0 mW
+		LowAlarm:    PowerMilliWattBE{0x01, 0xf4}, // 0.05 mW
+		HighWarning: PowerMilliWattBE{0x1f, 0x40}, // 0.8 mW
+		LowWarning:  PowerMilliWattBE{0x03, 0xe8}, // 0.1 mW
+	}
+	tests := []struct {
+		v    PowerMilliWattBE
+		want AlarmState
+	}{
+		{PowerMilliWattBE{0x13, 0x88}, AlarmStateNormal},
+		{PowerMilliWattBE{0x27, 0x11}, AlarmStateHighAlarm},
+		{PowerMilliWattBE{0x23, 0x28}, AlarmStateHighWarning},
+		{PowerMilliWattBE{0x02, 0x00}, AlarmStateLowWarning},
+		{PowerMilliWattBE{0x00, 0x00}, AlarmStateLowAlarm},
+	}
+	for _, tt := range tests {
+		if got := th.State(tt.v); got != tt.want {
+			t.Errorf("State(%s) = %s, want %s", tt.v, got, tt.want)
+		}
+	}
+
+	// Modules without thresholds report them as zero
+	if got := (PowerThresholds{}).State(PowerMilliWattBE{0x13, 0x88}); got != AlarmStateNone || got.IsAlarm() || got.IsWarning() {
+		t.Errorf("State() without thresholds = %s, want %s", got, AlarmStateNone)
+	}
+}
+
+func TestTemperatureThresholdsJSON(t *testing.T) {
+	th := TemperatureThresholds{
+		HighAlarm:   TemperatureQ8_8BE{0x5a, 0x00},
+		LowAlarm:    TemperatureQ8_8BE{0xf6, 0x00},
+		HighWarning: TemperatureQ8_8BE{0x55, 0x00},
+		LowWarning:  TemperatureQ8_8BE{0xfb, 0x00},
+	}
+	b, err := json.Marshal(th)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var got TemperatureThresholds
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got != th {
+		t.Errorf("Round trip gave %+v, want %+v", got, th)
+	}
+	if s := th.State(TemperatureQ8_8BE{0x58, 0x00}); s != AlarmStateHighWarning || !s.IsWarning() {
+		t.Errorf("State(88 C) = %s, want %s", s, AlarmStateHighWarning)
+	}
+}
@@ -18,6 +18,7 @@ func stringToJSON(b []byte) ([]byte, error) {
 
 type String2 [2]byte
 type String4 [4]byte
+type String10 [10]byte
 type String16 [16]byte
 
 func (s String2) String() string {
@@ -82,6 +83,37 @@ func (s *String4) UnmarshalJSON(in []byte) error {
 	return nil
 }
 
+func (s String10) String() string {
+	return strings.TrimSpace(string([]byte(s[:10])))
+}
+
+func (s String10) MarshalJSON() ([]byte, error) {
+	return stringToJSON([]byte(s[:10]))
+}
+
+func (s *String10) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < 10 {
+		return fmt.Errorf("length is shorter then String10 type")
+	}
+
+	*s = String10{}
+	for i := 0; i < 10; i++ {
+		s[i] = b[i]
+	}
+	return nil
+}
+
 func (s String16) String() string {
 	return strings.TrimSpace(string([]byte(s[:16])))
 }
@@ -306,6 +338,91 @@ func (i *Int16BE) UnmarshalJSON(in []byte) error {
 	return nil
 }
 
+// IEEE 754 single precision floating point number stored in big-endian byte order
+type Float32BE [4]byte
+
+func (f Float32BE) Float32() float32 {
+	return math.Float32frombits(uint32(f[0])<<24 | uint32(f[1])<<16 | uint32(f[2])<<8 | uint32(f[3]))
+}
+
+func (f Float32BE) String() string {
+	return fmt.Sprintf("%g", f.Float32())
+}
+
+// MarshalJSON encodes NaN and infinite values, e.g. unset calibration
+// constants of FFFFFFFFh, with a null value as JSON has no number for them
+func (f Float32BE) MarshalJSON() ([]byte, error) {
+	var v interface{}
+	if x := float64(f.Float32()); !math.IsNaN(x) && !math.IsInf(x, 0) {
+		v = f.Float32()
+	}
+	m := map[string]interface{}{
+		"value": v,
+		"hex":   hex.EncodeToString(f[:]),
+	}
+	return json.Marshal(m)
+}
+
+func (f *Float32BE) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < 4 {
+		return fmt.Errorf("length is shorter then Float32BE type")
+	}
+
+	*f = Float32BE{b[0], b[1], b[2], b[3]}
+	return nil
+}
+
+// Unsigned fixed-point number with 8 integer and 8 fractional bits stored in
+// big-endian byte order, used for calibration slopes
+type UFixed8_8BE [2]byte
+
+func (u UFixed8_8BE) Float64() float64 {
+	return float64(uint16(u[0])<<8|uint16(u[1])) / 256.0
+}
+
+func (u UFixed8_8BE) String() string {
+	return fmt.Sprintf("%.4f", u.Float64())
+}
+
+func (u UFixed8_8BE) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": u.Float64(),
+		"hex":   hex.EncodeToString([]byte{u[0], u[1]}),
+	}
+	return json.Marshal(m)
+}
+
+func (u *UFixed8_8BE) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < 2 {
+		return fmt.Errorf("length is shorter then UFixed8_8BE type")
+	}
+
+	*u = UFixed8_8BE{b[0], b[1]}
+	return nil
+}
+
 // Temperature in q8.8 fixed-point format stored in big-endian byte order.
 // Value is a signed two's complement 16-bit integer with 8 fractional bits.
 // Range: [-128.000, 127.996] °C with granularity of 1/256 °C.
@@ -384,9 +501,14 @@ func (p PowerMilliWattBE) String() string {
 }
 
 func (p PowerMilliWattBE) MarshalJSON() ([]byte, error) {
+	// JSON has no representation of -inf, zero power has no dBm value
+	var dBm interface{}
+	if p.Raw() != 0 {
+		dBm = p.DBm()
+	}
 	m := map[string]interface{}{
 		"mW":  p.MilliWatt(),
-		"dBm": p.DBm(),
+		"dBm": dBm,
 		"hex": hex.EncodeToString([]byte{p[0], p[1]}),
 	}
 	return json.Marshal(m)
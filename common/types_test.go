@@ -0,0 +1,33 @@
+package common
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPowerZeroJSON(t *testing.T) {
+	b, err := json.Marshal(PowerMilliWattBE{})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(b), `"dBm":null`) {
+		t.Errorf("Marshal() = %s, want null dBm", b)
+	}
+}
+
+func TestFloat32NaNJSON(t *testing.T) {
+	for _, f := range []Float32BE{{0xff, 0xff, 0xff, 0xff}, {0x7f, 0x80, 0x00, 0x00}} {
+		b, err := json.Marshal(f)
+		if err != nil {
+			t.Fatalf("Marshal(%x) failed: %v", f[:], err)
+		}
+		if !strings.Contains(string(b), `"value":null`) {
+			t.Errorf("Marshal(%x) = %s, want null value", f[:], b)
+		}
+		var got Float32BE
+		if err := json.Unmarshal(b, &got); err != nil || got != f {
+			t.Errorf("Unmarshal(%s) = %x, %v, want %x", b, got[:], err, f[:])
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package sff
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bluecmd/go-sff/common"
+	"github.com/bluecmd/go-sff/sff8636"
+)
+
+// PagedWriter interface defines how to write a region of SFF module memory
+type PagedWriter interface {
+	WriteRegion(r common.Region, b []byte) error
+}
+
+// PagedReadWriter is the interface required by Controller
+type PagedReadWriter interface {
+	PagedReader
+	PagedWriter
+}
+
+var (
+	// ErrNotSupported is returned for operations the module does not
+	// advertise
+	ErrNotSupported = errors.New("not supported by module")
+	// ErrVerifyFailed is returned if a written value does not read back
+	ErrVerifyFailed = errors.New("read back differs from written value")
+)
+
+// Controller changes the operational configuration of a module. Each
+// operation first reads the module to check that it advertises the
+// capability, and verifies the write by reading the byte back.
+type Controller struct {
+	rw PagedReadWriter
+
+	resetTimeout time.Duration // Time a module may take to reset
+	pollInterval time.Duration // Interval of polling a resetting module
+}
+
+// NewController creates a new Controller using the provided PagedReadWriter
+func NewController(rw PagedReadWriter) *Controller {
+	// SFF-8636 allows t_init of 2 s after a reset before the module
+	// responds
+	return &Controller{rw: rw, resetTimeout: 2 * time.Second, pollInterval: 50 * time.Millisecond}
+}
+
+// NewI2CController creates a new Controller for the module on the given I2C
+// device path
+func NewI2CController(path string) *Controller {
+	return NewController(NewI2CReader(path))
+}
+
+// Module reads and decodes the module
+func (c *Controller) Module() (*Module, error) {
+	return ReadPaged(c.rw)
+}
+
+// byteRegion returns the region of the single byte at offset of the given
+// page of address
+func byteRegion(address, page uint8, offset int) common.Region {
+	return common.Region{Address: address, Page: page, Offset: offset, Length: 1}
+}
+
+// update sets the bits in mask of the byte in r to value. Unless verify is
+// false, the byte is read back and compared.
+func (c *Controller) update(r common.Region, mask, value byte, verify bool) error {
+	b, err := c.rw.ReadRegion(r)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", r, err)
+	}
+	v := b[0]&^mask | value&mask
+	if err := c.rw.WriteRegion(r, []byte{v}); err != nil {
+		return fmt.Errorf("writing %s: %w", r, err)
+	}
+	if !verify {
+		return nil
+	}
+	b, err = c.rw.ReadRegion(r)
+	if err != nil {
+		return fmt.Errorf("reading back %s: %w", r, err)
+	}
+	if b[0]&mask != v&mask {
+		return fmt.Errorf("%s: wrote 0x%02x, read back 0x%02x: %w", r, v, b[0], ErrVerifyFailed)
+	}
+	return nil
+}
+
+func bit(set bool, mask byte) byte {
+	if set {
+		return mask
+	}
+	return 0
+}
+
+// SetTxDisable disables or enables the transmitter of channel (1-4). SFP
+// modules have a single channel controlled by Soft TX_DISABLE (A2h byte 110
+// bit 6), QSFP modules use the Tx_Disable bits (byte 86).
+func (c *Controller) SetTxDisable(channel int, disable bool) error {
+	m, err := c.Module()
+	if err != nil {
+		return err
+	}
+	switch m.Type {
+	case TypeSff8079:
+		if channel != 1 {
+			return fmt.Errorf("invalid channel %d, SFP modules have one channel", channel)
+		}
+		if !m.Sff8079.EnhancedOpts.IsSoftTxDisableImplemented() || !m.Memory.Has(common.LowerPage(common.AddressA2)) {
+			return fmt.Errorf("soft TX_DISABLE: %w", ErrNotSupported)
+		}
+		return c.update(byteRegion(common.AddressA2, 0, 110), 0x40, bit(disable, 0x40), true)
+	case TypeSff8636:
+		if channel < 1 || channel > 4 {
+			return fmt.Errorf("invalid channel %d, QSFP modules have channels 1-4", channel)
+		}
+		if _, _, o := m.Sff8636.Options.Decode(); !o.TxDisableImplemented {
+			return fmt.Errorf("Tx_Disable: %w", ErrNotSupported)
+		}
+		mask := byte(1) << (channel - 1)
+		return c.update(byteRegion(common.AddressA0, 0, 86), mask, bit(disable, mask), true)
+	}
+	return fmt.Errorf("Tx disable on %s modules: %w", m.Type, ErrNotSupported)
+}
+
+// SetLowPowerMode sets the Power_override bit (byte 93 bit 0) of a QSFP
+// module so that Power_set (bit 1) instead of the LPMode pin controls low
+// power mode, and sets Power_set to enable. Power_override and Power_set are
+// required of all SFF-8636 and SFF-8436 modules, there is no capability to
+// check.
+func (c *Controller) SetLowPowerMode(enable bool) error {
+	m, err := c.Module()
+	if err != nil {
+		return err
+	}
+	if m.Type != TypeSff8636 {
+		return fmt.Errorf("low power mode on %s modules: %w", m.Type, ErrNotSupported)
+	}
+	return c.update(byteRegion(common.AddressA0, 0, 93), 0x03, 0x01|bit(enable, 0x02), true)
+}
+
+// ClearPowerOverride returns low power mode control of a QSFP module to the
+// LPMode pin (byte 93 bit 0). Like SetLowPowerMode it is available on all QSFP
+// modules.
+func (c *Controller) ClearPowerOverride() error {
+	m, err := c.Module()
+	if err != nil {
+		return err
+	}
+	if m.Type != TypeSff8636 {
+		return fmt.Errorf("power override on %s modules: %w", m.Type, ErrNotSupported)
+	}
+	return c.update(byteRegion(common.AddressA0, 0, 93), 0x01, 0, true)
+}
+
+// SoftwareReset resets a QSFP module (byte 93 bit 7) if software reset is
+// implemented according to the Enhanced Options (byte 221). The bit clears
+// itself once the module resets, so instead of reading it back SoftwareReset
+// waits until the bit is clear and Data_Not_Ready (byte 2 bit 0) is
+// deasserted. Read errors while the module resets are ignored.
+func (c *Controller) SoftwareReset() error {
+	m, err := c.Module()
+	if err != nil {
+		return err
+	}
+	if m.Type != TypeSff8636 || !m.Sff8636.EnhOptions.IsSoftwareResetImplemented() {
+		return fmt.Errorf("software reset on %s modules: %w", m.Type, ErrNotSupported)
+	}
+	if err := c.update(byteRegion(common.AddressA0, 0, 93), 0x80, 0x80, false); err != nil {
+		return err
+	}
+	return c.waitReady()
+}
+
+// waitReady polls a QSFP module until the software reset bit (byte 93 bit 7)
+// and Data_Not_Ready (byte 2 bit 0) are clear
+func (c *Controller) waitReady() error {
+	status := byteRegion(common.AddressA0, 0, 2)
+	control := byteRegion(common.AddressA0, 0, 93)
+	deadline := time.Now().Add(c.resetTimeout)
+	err := ErrVerifyFailed
+	for {
+		time.Sleep(c.pollInterval)
+		s, serr := c.rw.ReadRegion(status)
+		b, cerr := c.rw.ReadRegion(control)
+		switch {
+		case serr != nil:
+			err = serr
+		case cerr != nil:
+			err = cerr
+		case sff8636.Status(s[0]).IsDataNotReady() || b[0]&0x80 != 0:
+			err = ErrVerifyFailed
+		default:
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("module not ready %s after software reset: %w", c.resetTimeout, err)
+		}
+	}
+}
+
+// SetRateSelect sets Soft Rate_Select RS(0) (A2h byte 110 bit 3) and Soft
+// RS(1) (A2h byte 118 bit 3) of an SFP module
+func (c *Controller) SetRateSelect(rs0, rs1 bool) error {
+	m, err := c.Module()
+	if err != nil {
+		return err
+	}
+	if m.Type != TypeSff8079 {
+		return fmt.Errorf("soft rate select on %s modules: %w", m.Type, ErrNotSupported)
+	}
+	if !m.Sff8079.EnhancedOpts.IsSoftRateSelectImplemented() || !m.Memory.Has(common.LowerPage(common.AddressA2)) {
+		return fmt.Errorf("soft rate select: %w", ErrNotSupported)
+	}
+	if err := c.update(byteRegion(common.AddressA2, 0, 110), 0x08, bit(rs0, 0x08), true); err != nil {
+		return err
+	}
+	return c.update(byteRegion(common.AddressA2, 0, 118), 0x08, bit(rs1, 0x08), true)
+}
@@ -0,0 +1,285 @@
+package sff
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+// fakeModule implements PagedReadWriter on top of paged memory. Bytes in
+// readOnly ignore writes, like registers a module does not implement. A
+// software reset (byte 93 bit 7) sets Data_Not_Ready for the next resetReads
+// reads of the status byte, or forever if resetReads is negative.
+type fakeModule struct {
+	mem        *common.Memory
+	readOnly   map[common.Region]bool
+	writes     int
+	resets     int
+	resetReads int
+	resetting  int
+}
+
+func newFakeModule(t *testing.T, name string) *fakeModule {
+	b, err := os.ReadFile("testdata/" + name + ".bin")
+	if err != nil {
+		t.Fatalf("Failed to read EEPROM file: %v", err)
+	}
+	return &fakeModule{mem: MemoryFromFlat(b), readOnly: map[common.Region]bool{}}
+}
+
+var (
+	qsfpStatus  = byteRegion(common.AddressA0, 0, 2)
+	qsfpControl = byteRegion(common.AddressA0, 0, 93)
+)
+
+func (f *fakeModule) ReadRegion(r common.Region) ([]byte, error) {
+	if r == qsfpStatus && f.resetting != 0 {
+		f.resetting--
+		if f.resetting == 0 {
+			f.set(qsfpStatus, f.get(qsfpStatus)&^0x01)
+		}
+	}
+	return f.mem.Read(r)
+}
+
+func (f *fakeModule) WriteRegion(r common.Region, b []byte) error {
+	f.writes++
+	if f.readOnly[r] {
+		return nil
+	}
+	if r == qsfpControl && b[0]&0x80 != 0 {
+		f.resets++
+		f.resetting = f.resetReads
+		f.set(qsfpStatus, f.get(qsfpStatus)|0x01)
+		return f.mem.Write(r, []byte{b[0] &^ 0x80})
+	}
+	return f.mem.Write(r, b)
+}
+
+// set changes a byte of the fake module memory
+func (f *fakeModule) set(r common.Region, v byte) {
+	f.mem.Write(r, []byte{v})
+}
+
+func (f *fakeModule) get(r common.Region) byte {
+	b, _ := f.mem.Read(r)
+	return b[0]
+}
+
+func TestControllerQsfp(t *testing.T) {
+	f := newFakeModule(t, "IN-Q2AY2-35")
+	c := NewController(f)
+	txDisable := byteRegion(common.AddressA0, 0, 86)
+	control := byteRegion(common.AddressA0, 0, 93)
+
+	if err := c.SetTxDisable(3, true); err != nil {
+		t.Fatalf("SetTxDisable failed: %v", err)
+	}
+	if err := c.SetTxDisable(1, true); err != nil {
+		t.Fatalf("SetTxDisable failed: %v", err)
+	}
+	if err := c.SetTxDisable(3, false); err != nil {
+		t.Fatalf("SetTxDisable failed: %v", err)
+	}
+	if v := f.get(txDisable); v != 0x01 {
+		t.Errorf("Tx_Disable = 0x%02x, want 0x01", v)
+	}
+	if err := c.SetTxDisable(5, true); err == nil {
+		t.Error("Expected error for channel 5")
+	}
+
+	if err := c.SetLowPowerMode(true); err != nil {
+		t.Fatalf("SetLowPowerMode failed: %v", err)
+	}
+	if v := f.get(control); v&0x03 != 0x03 {
+		t.Errorf("Control = 0x%02x, want Power_set and Power_override", v)
+	}
+	if err := c.ClearPowerOverride(); err != nil {
+		t.Fatalf("ClearPowerOverride failed: %v", err)
+	}
+	if v := f.get(control); v&0x03 != 0x02 {
+		t.Errorf("Control = 0x%02x, want Power_set only", v)
+	}
+
+	// Writes that do not stick are reported
+	f.readOnly[txDisable] = true
+	if err := c.SetTxDisable(2, true); !errors.Is(err, ErrVerifyFailed) {
+		t.Errorf("Expected ErrVerifyFailed, got %v", err)
+	}
+}
+
+func TestControllerQsfpNotSupported(t *testing.T) {
+	f := newFakeModule(t, "IN-Q2AY2-35")
+	c := NewController(f)
+
+	// Tx_Disable implemented (byte 195 bit 4) cleared
+	options := byteRegion(common.AddressA0, 0, 195)
+	f.set(options, f.get(options)&^0x10)
+	if err := c.SetTxDisable(1, true); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Expected ErrNotSupported, got %v", err)
+	}
+
+	// Software reset is not implemented by the fixture (byte 221 bit 0)
+	if err := c.SoftwareReset(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Expected ErrNotSupported, got %v", err)
+	}
+	if err := c.SetRateSelect(true, true); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Expected ErrNotSupported for SFP rate select, got %v", err)
+	}
+	if f.writes != 0 {
+		t.Errorf("Got %d writes to a module without the capabilities", f.writes)
+	}
+
+	enhOptions := byteRegion(common.AddressA0, 0, 221)
+	f.set(enhOptions, f.get(enhOptions)|0x01)
+	c.pollInterval = time.Millisecond
+	f.resetReads = 3
+	if err := c.SoftwareReset(); err != nil {
+		t.Fatalf("SoftwareReset failed: %v", err)
+	}
+	if f.resets != 1 || f.resetting != 0 {
+		t.Errorf("Got %d resets, %d status reads pending, want 1 reset and a ready module", f.resets, f.resetting)
+	}
+
+	// Modules that do not become ready are reported
+	c.resetTimeout = 10 * time.Millisecond
+	f.resetReads = -1
+	if err := c.SoftwareReset(); !errors.Is(err, ErrVerifyFailed) {
+		t.Errorf("Expected ErrVerifyFailed, got %v", err)
+	}
+}
+
+func TestControllerSfp(t *testing.T) {
+	f := newFakeModule(t, "FLEX-P.8596.02")
+	c := NewController(f)
+	enhOptions := byteRegion(common.AddressA0, 0, 93)
+	status := byteRegion(common.AddressA2, 0, 110)
+	extStatus := byteRegion(common.AddressA2, 0, 118)
+
+	f.set(enhOptions, 0x00)
+	if err := c.SetTxDisable(1, true); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Expected ErrNotSupported, got %v", err)
+	}
+	if err := c.SetRateSelect(true, false); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Expected ErrNotSupported, got %v", err)
+	}
+
+	f.set(enhOptions, 0x40|0x08)
+	f.set(status, 0x00)
+	f.set(extStatus, 0x00)
+	if err := c.SetTxDisable(1, true); err != nil {
+		t.Fatalf("SetTxDisable failed: %v", err)
+	}
+	if err := c.SetTxDisable(2, true); err == nil {
+		t.Error("Expected error for channel 2")
+	}
+	if err := c.SetRateSelect(true, true); err != nil {
+		t.Fatalf("SetRateSelect failed: %v", err)
+	}
+	if v := f.get(status); v != 0x48 {
+		t.Errorf("Status/Control = 0x%02x, want 0x48", v)
+	}
+	if v := f.get(extStatus); v != 0x08 {
+		t.Errorf("Extended Status/Control = 0x%02x, want 0x08", v)
+	}
+	if err := c.SetLowPowerMode(true); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Expected ErrNotSupported for QSFP low power mode, got %v", err)
+	}
+}
+
+// fakeI2CBus emulates the I2C devices of a module on top of paged memory.
+// Byte 127 of A0h selects the upper page, like on a real module.
+type fakeI2CBus struct {
+	mem     *common.Memory
+	pointer map[uint8]byte
+}
+
+// fakeI2CConn is a connection to one device address of a fakeI2CBus
+type fakeI2CConn struct {
+	bus  *fakeI2CBus
+	addr uint8
+}
+
+// region returns the byte at offset of the page currently selected
+func (c *fakeI2CConn) region(offset byte) common.Region {
+	r := byteRegion(c.addr, 0, int(offset))
+	if r.IsUpper() && c.addr == common.AddressA0 {
+		b, _ := c.bus.mem.Read(byteRegion(c.addr, 0, 127))
+		r.Page = b[0]
+	}
+	return r
+}
+
+func (c *fakeI2CConn) Write(b []byte) (int, error) {
+	offset := b[0]
+	for _, v := range b[1:] {
+		if err := c.bus.mem.Write(c.region(offset), []byte{v}); err != nil {
+			return 0, err
+		}
+		offset++
+	}
+	c.bus.pointer[c.addr] = offset
+	return len(b), nil
+}
+
+func (c *fakeI2CConn) Read(b []byte) (int, error) {
+	for i := range b {
+		d, err := c.bus.mem.Read(c.region(c.bus.pointer[c.addr]))
+		if err != nil {
+			return i, io.ErrUnexpectedEOF
+		}
+		b[i] = d[0]
+		c.bus.pointer[c.addr]++
+	}
+	return len(b), nil
+}
+
+func (c *fakeI2CConn) Close() error {
+	return nil
+}
+
+func newFakeI2CReader(mem *common.Memory) *I2CReader {
+	bus := &fakeI2CBus{mem: mem, pointer: map[uint8]byte{}}
+	return &I2CReader{path: "fake", open: func(addr uint8) (*I2C, error) {
+		return &I2C{rc: &fakeI2CConn{bus: bus, addr: addr}}, nil
+	}}
+}
+
+func TestI2CReaderPageSelect(t *testing.T) {
+	f := newFakeModule(t, "IN-Q2AY2-35")
+	// Page 03h is always present on paged modules, the dump lacks it
+	f.mem.Write(common.UpperPage(common.AddressA0, 0, 3), make([]byte, common.PageLen))
+	r := newFakeI2CReader(f.mem)
+	page02 := byteRegion(common.AddressA0, 2, 200)
+	pageSelect := byteRegion(common.AddressA0, 0, 127)
+
+	if err := r.WriteRegion(page02, []byte{0x5a}); err != nil {
+		t.Fatalf("WriteRegion failed: %v", err)
+	}
+	if v := f.get(page02); v != 0x5a {
+		t.Errorf("Page 02h byte 200 = 0x%02x, want 0x5a", v)
+	}
+	if b, err := r.ReadRegion(page02); err != nil || b[0] != 0x5a {
+		t.Errorf("ReadRegion() = % x, %v, want 5a", b, err)
+	}
+	// The module is left on page 00h for other users of the bus
+	if v := f.get(pageSelect); v != 0 {
+		t.Errorf("Page select = 0x%02x after access to page 02h, want 0x00", v)
+	}
+
+	// Controller operations go through the same page select
+	c := NewController(r)
+	if err := c.SetTxDisable(2, true); err != nil {
+		t.Fatalf("SetTxDisable failed: %v", err)
+	}
+	if v := f.get(byteRegion(common.AddressA0, 0, 86)); v != 0x02 {
+		t.Errorf("Tx_Disable = 0x%02x, want 0x02", v)
+	}
+	if v := f.get(pageSelect); v != 0 {
+		t.Errorf("Page select = 0x%02x after SetTxDisable, want 0x00", v)
+	}
+}
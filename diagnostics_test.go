@@ -0,0 +1,107 @@
+package sff
+
+import (
+	"math"
+	"os"
+	"testing"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+func readTestModule(t *testing.T, name string) *Module {
+	t.Helper()
+	b, err := os.ReadFile("testdata/" + name + ".bin")
+	if err != nil {
+		t.Fatalf("Failed to read EEPROM file: %v", err)
+	}
+	m, err := Read(&MockReader{data: b})
+	if err != nil {
+		t.Fatalf("Failed to read module: %v", err)
+	}
+	return m
+}
+
+func TestDiagnostics(t *testing.T) {
+	tests := []struct {
+		name      string
+		lanes     int
+		tempState common.AlarmState
+		lane      int     // Lane to check
+		txPower   float64 // mW
+		rxPowerDb float64 // dBm
+	}{
+		{"FLEX-P.8596.02", 1, common.AlarmStateNormal, 1, 0.5119, -1.78},
+		{"TR-FC85S-N00", 4, common.AlarmStateNormal, 3, 1.1618, -0.90},
+		// Latched temperature low alarm flag, the module has no page 03h
+		{"IN-Q2AY2-35", 4, common.AlarmStateLowAlarm, 1, 0, math.Inf(-1)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var p common.DiagnosticsProvider = readTestModule(t, test.name)
+			d := p.Diagnostics()
+			if d == nil {
+				t.Fatal("Diagnostics() = nil")
+			}
+			if len(d.Lanes) != test.lanes {
+				t.Fatalf("Got %d lanes, want %d", len(d.Lanes), test.lanes)
+			}
+			if d.TemperatureState != test.tempState {
+				t.Errorf("TemperatureState = %s, want %s", d.TemperatureState, test.tempState)
+			}
+			l := d.Lanes[test.lane-1]
+			if l.Lane != test.lane {
+				t.Errorf("Lane = %d, want %d", l.Lane, test.lane)
+			}
+			if math.Abs(l.TxPower.MilliWatt()-test.txPower) > 1e-6 {
+				t.Errorf("TxPower = %f mW, want %f mW", l.TxPower.MilliWatt(), test.txPower)
+			}
+			if db := l.RxPower.DBm(); math.Abs(db-test.rxPowerDb) > 0.01 && !(math.IsInf(db, -1) && math.IsInf(test.rxPowerDb, -1)) {
+				t.Errorf("RxPower = %f dBm, want %f dBm", db, test.rxPowerDb)
+			}
+		})
+	}
+
+	if d := readTestModule(t, "CMIS-QDD-400G-DR4").Diagnostics(); d != nil {
+		t.Errorf("Expected no diagnostics for CMIS module, got %+v", d)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	for _, name := range []string{"FLEX-P.8596.02", "TR-FC85S-N00"} {
+		t.Run(name, func(t *testing.T) {
+			f := newFakeModule(t, name)
+			m, err := ReadPaged(f)
+			if err != nil {
+				t.Fatalf("Failed to read module: %v", err)
+			}
+			before := m.Diagnostics().Lanes[0].RxPower
+
+			// Rx power of lane 1, and a vendor name change that must not be
+			// picked up as it is outside of the monitors
+			r := monitorRegions[m.Type]
+			rx := common.Region{Address: r.Address, Offset: 104, Length: 2}
+			if m.Type == TypeSff8636 {
+				rx.Offset = 34
+			}
+			f.mem.Write(rx, []byte{0x12, 0x34})
+			f.set(common.Region{Address: common.AddressA0, Offset: 148, Length: 1}, 'X')
+
+			if err := m.Refresh(f); err != nil {
+				t.Fatalf("Refresh failed: %v", err)
+			}
+			after := m.Diagnostics().Lanes[0].RxPower
+			if after.Raw() != 0x1234 || after == before {
+				t.Errorf("RxPower = %04x after refresh, want 1234", after.Raw())
+			}
+			if inv := m.Inventory(); inv.Vendor[0] == 'X' {
+				t.Errorf("Vendor %q was re-read", inv.Vendor)
+			}
+		})
+	}
+
+	m := readTestModule(t, "CMIS-QDD-400G-DR4")
+	if err := m.Refresh(newFakeModule(t, "CMIS-QDD-400G-DR4")); err == nil {
+		t.Error("Expected error refreshing CMIS module")
+	}
+}
@@ -0,0 +1,363 @@
+package sff
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bluecmd/go-sff/cmis"
+	"github.com/bluecmd/go-sff/common"
+)
+
+// ParseFlat converts a flat EEPROM dump to paged memory like MemoryFromFlat,
+// but returns an error if the dump is shorter than 256 bytes or ends within a
+// half page, instead of decoding missing bytes as zeros
+func ParseFlat(eeprom []byte) (*common.Memory, error) {
+	if len(eeprom) < 2*common.PageLen {
+		return nil, fmt.Errorf("dump of %d bytes is shorter than %d bytes", len(eeprom), 2*common.PageLen)
+	}
+	if isSff8079(eeprom[0]) {
+		return common.ParseFlatSff8079(eeprom)
+	}
+	if cmis.IsCmis(eeprom[0]) {
+		return common.ParseFlatCmis(eeprom)
+	}
+	return common.ParseFlatSff8636(eeprom)
+}
+
+var (
+	// ethtool -m hex on: "0x0010:\t\t08 02 00 1e ..."
+	ethtoolHexLine = regexp.MustCompile(`^\s*0x([0-9a-fA-F]+):\s+(.*)$`)
+	// Page selection of the following block, as given to ethtool:
+	// "ethtool -m eth0 page 3 offset 128 length 128 hex on"
+	ethtoolPageArg = regexp.MustCompile(`\b(page|bank|i2c)\s+(0[xX][0-9a-fA-F]+|[0-9]+)\b`)
+	// i2cdump: "10: 08 02 00 1e ...    ???.FLEX"
+	i2cdumpLine = regexp.MustCompile(`^([0-9a-f]0):((?: (?:[0-9a-f]{2}|XX)){16})`)
+)
+
+// ParseDump parses an EEPROM dump in one of the formats collected from
+// modules in the field:
+//
+//   - raw binary dumps, such as "ethtool -m <if> raw on" output
+//   - "ethtool -m <if> hex on" output, including the per page blocks of
+//     ethtool's netlink mode
+//   - "i2cdump -y <bus> 0x50" output, optionally followed by the 0x51 table
+//     of SFP modules
+//   - hex strings, optionally separated by whitespace, colons or commas
+//
+// The dump is returned in the flat layout of MemoryFromFlat, binary dumps
+// as is. Pages missing between the pages of an ethtool hex dump are zero in
+// the flat layout, NewDumpReader keeps them apart.
+func ParseDump(b []byte) ([]byte, error) {
+	flat, _, err := parseDump(b)
+	return flat, err
+}
+
+// parseDump is ParseDump, also returning the paged memory of ethtool hex
+// dumps. The memory is nil for the other formats, see ParseFlat.
+func parseDump(b []byte) ([]byte, *common.Memory, error) {
+	if !isText(b) {
+		return b, nil, nil
+	}
+
+	switch {
+	case matchLine(b, ethtoolHexLine):
+		mem, err := parseEthtoolHex(b)
+		if err != nil {
+			return nil, nil, err
+		}
+		return flatMemory(mem), mem, nil
+	case matchLine(b, i2cdumpLine):
+		flat, err := parseI2cdump(b)
+		return flat, nil, err
+	}
+	flat, err := parseHexString(b)
+	return flat, nil, err
+}
+
+// isText returns true if b only holds printable ASCII and line breaks. Binary
+// dumps start with an SFF-8024 identifier below 20h.
+func isText(b []byte) bool {
+	for _, c := range b {
+		if (c < 0x20 || c > 0x7e) && c != '\t' && c != '\n' && c != '\r' {
+			return false
+		}
+	}
+	return len(b) > 0
+}
+
+func matchLine(b []byte, re *regexp.Regexp) bool {
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		if re.MatchString(s.Text()) {
+			return true
+		}
+	}
+	return false
+}
+
+// hexBlock is a block of consecutive offset lines of an ethtool hex dump
+type hexBlock struct {
+	line    int  // Line of the first offset
+	paged   bool // Selected by page, bank or i2c, offsets are 0-255 of the page
+	address uint8
+	bank    uint8
+	page    uint8
+	offset  int
+	data    []byte
+}
+
+// parseEthtoolHex parses the blocks of offset lines, each starting at an
+// "Offset" header. Offsets must be consecutive within a block.
+//
+// Blocks preceded by a line selecting the page, bank or I2C address like
+// ethtool's arguments ("page 3", "bank 0", "i2c 0x51") are stored in that
+// page. Other blocks use the offsets of the flat layout, so a block at 0x80
+// is upper page 00h and a block at 0x180 upper page 02h of a QSFP module.
+// Bytes given twice are rejected, as ethtool's netlink mode prints all
+// upper pages at offset 0x80 and they cannot be told apart.
+func parseEthtoolHex(b []byte) (*common.Memory, error) {
+	var blocks []*hexBlock
+	var block, sel *hexBlock
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; s.Scan(); n++ {
+		line := s.Text()
+		m := ethtoolHexLine.FindStringSubmatch(line)
+		if m == nil {
+			if strings.HasPrefix(strings.TrimSpace(line), "Offset") {
+				block = nil
+				continue
+			}
+			args := ethtoolPageArg.FindAllStringSubmatch(line, -1)
+			if args == nil {
+				continue
+			}
+			sel = &hexBlock{paged: true, address: common.AddressA0}
+			for _, a := range args {
+				v, err := strconv.ParseUint(a[2], 0, 8)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %s: %w", n, a[1], err)
+				}
+				switch a[1] {
+				case "page":
+					sel.page = uint8(v)
+				case "bank":
+					sel.bank = uint8(v)
+				case "i2c":
+					sel.address = uint8(v)
+				}
+			}
+			block = nil
+			continue
+		}
+		offset, err := strconv.ParseUint(m[1], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if block == nil {
+			block = &hexBlock{address: common.AddressA0}
+			if sel != nil {
+				block, sel = sel, nil
+			}
+			block.line, block.offset = n, int(offset)
+			blocks = append(blocks, block)
+		} else if next := block.offset + len(block.data); int(offset) != next {
+			return nil, fmt.Errorf("line %d: offset 0x%04x, want 0x%04x", n, offset, next)
+		}
+		d, err := hex.DecodeString(strings.Join(strings.Fields(m[2]), ""))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		block.data = append(block.data, d...)
+	}
+
+	mem, err := ethtoolFlatBlocks(blocks)
+	if err != nil {
+		return nil, err
+	}
+	if err := ethtoolPagedBlocks(mem, blocks); err != nil {
+		return nil, err
+	}
+	if !mem.Has(common.Region{Address: common.AddressA0, Offset: 0, Length: 2 * common.PageLen}) {
+		return nil, fmt.Errorf("dump does not hold the A0h lower page and upper page 00h")
+	}
+	return mem, nil
+}
+
+// ethtoolFlatBlocks combines the blocks without page selection to a flat
+// dump, which must be complete up to the last half page
+func ethtoolFlatBlocks(blocks []*hexBlock) (*common.Memory, error) {
+	var flat []byte
+	var covered []bool
+	for _, b := range blocks {
+		if b.paged {
+			continue
+		}
+		if end := b.offset + len(b.data); end > len(flat) {
+			flat = append(flat, make([]byte, end-len(flat))...)
+			covered = append(covered, make([]bool, end-len(covered))...)
+		}
+		for i, c := range b.data {
+			if covered[b.offset+i] {
+				return nil, fmt.Errorf("line %d: offset 0x%04x given twice, select the page of per page blocks", b.line, b.offset+i)
+			}
+			flat[b.offset+i], covered[b.offset+i] = c, true
+		}
+	}
+	for i, c := range covered {
+		if !c {
+			return nil, fmt.Errorf("offset 0x%04x missing", i)
+		}
+	}
+	if len(flat)%common.PageLen != 0 {
+		return nil, fmt.Errorf("dump of %d bytes ends within a half page", len(flat))
+	}
+	return MemoryFromFlat(flat), nil
+}
+
+// ethtoolPagedBlocks stores the blocks with page selection in mem. Every
+// half page written must be complete and not present before.
+func ethtoolPagedBlocks(mem *common.Memory, blocks []*hexBlock) error {
+	type halfPage struct {
+		line    int
+		data    [common.PageLen]byte
+		covered [common.PageLen]bool
+	}
+	pages := map[common.Region]*halfPage{}
+	var order []common.Region
+	for _, b := range blocks {
+		if !b.paged {
+			continue
+		}
+		if b.offset+len(b.data) > 2*common.PageLen {
+			return fmt.Errorf("line %d: block exceeds offset 0x%02x of the page", b.line, 2*common.PageLen-1)
+		}
+		for i, c := range b.data {
+			o := b.offset + i
+			r := common.LowerPage(b.address)
+			if o >= common.PageLen {
+				r = common.UpperPage(b.address, b.bank, b.page)
+			}
+			p, ok := pages[r]
+			if !ok {
+				if mem.Has(r) {
+					return fmt.Errorf("line %d: %s given twice", b.line, r)
+				}
+				p = &halfPage{line: b.line}
+				pages[r] = p
+				order = append(order, r)
+			}
+			if p.covered[o%common.PageLen] {
+				return fmt.Errorf("line %d: %s offset %d given twice", b.line, r, o)
+			}
+			p.data[o%common.PageLen], p.covered[o%common.PageLen] = c, true
+		}
+	}
+	for _, r := range order {
+		p := pages[r]
+		for i, c := range p.covered {
+			if !c {
+				return fmt.Errorf("line %d: %s incomplete, offset %d missing", p.line, r, r.Offset+i)
+			}
+		}
+		if err := mem.Write(r, p.data[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseI2cdump converts i2cdump tables of 256 bytes each. The first table is
+// A0h (0x50), a second table is A2h (0x51) and only valid for SFP modules.
+func parseI2cdump(b []byte) ([]byte, error) {
+	var tables [][]byte
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; s.Scan(); n++ {
+		m := i2cdumpLine.FindStringSubmatch(s.Text())
+		if m == nil {
+			continue
+		}
+		row, _ := strconv.ParseUint(m[1], 16, 8)
+		if row == 0 {
+			tables = append(tables, nil)
+		}
+		t := len(tables) - 1
+		if t < 0 || len(tables[t]) != int(row) {
+			return nil, fmt.Errorf("line %d: unexpected row %s", n, m[1])
+		}
+		if strings.Contains(m[2], "XX") {
+			return nil, fmt.Errorf("line %d: row %s has bytes that failed to read", n, m[1])
+		}
+		d, err := hex.DecodeString(strings.ReplaceAll(m[2], " ", ""))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		tables[t] = append(tables[t], d...)
+	}
+
+	for i, t := range tables {
+		if len(t) != 2*common.PageLen {
+			return nil, fmt.Errorf("table %d has %d bytes, want %d", i+1, len(t), 2*common.PageLen)
+		}
+	}
+	switch {
+	case len(tables) == 0:
+		return nil, fmt.Errorf("no i2cdump table found")
+	case len(tables) > 2 || (len(tables) == 2 && !isSff8079(tables[0][0])):
+		return nil, fmt.Errorf("got %d tables, only SFP modules may have a second table for A2h", len(tables))
+	}
+	return bytes.Join(tables, nil), nil
+}
+
+// parseHexString decodes hex digits, ignoring whitespace, colons, commas and
+// 0x prefixes
+func parseHexString(b []byte) ([]byte, error) {
+	var digits strings.Builder
+	for _, f := range strings.FieldsFunc(string(b), func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == ':' || r == ','
+	}) {
+		digits.WriteString(strings.TrimPrefix(strings.TrimPrefix(f, "0x"), "0X"))
+	}
+	d, err := hex.DecodeString(digits.String())
+	if err != nil {
+		return nil, fmt.Errorf("parsing hex string: %w", err)
+	}
+	return d, nil
+}
+
+// DumpReader implements the Reader and PagedReader interfaces for a dump held
+// in memory, in any of the formats accepted by ParseDump
+type DumpReader struct {
+	flat []byte
+	mem  *common.Memory
+}
+
+// NewDumpReader parses dump, see ParseDump. Pages of ethtool hex dumps are
+// kept apart, so pages missing in between are not present. dump is copied.
+func NewDumpReader(dump []byte) (*DumpReader, error) {
+	flat, mem, err := parseDump(dump)
+	if err != nil {
+		return nil, err
+	}
+	if mem == nil {
+		if mem, err = ParseFlat(flat); err != nil {
+			return nil, err
+		}
+	}
+	return &DumpReader{flat: append([]byte{}, flat...), mem: mem}, nil
+}
+
+// Read implements the Reader interface. It returns the dump in the flat
+// layout, without padding.
+func (r *DumpReader) Read() ([]byte, error) {
+	return append([]byte{}, r.flat...), nil
+}
+
+// ReadRegion implements the PagedReader interface
+func (r *DumpReader) ReadRegion(region common.Region) ([]byte, error) {
+	return r.mem.Read(region)
+}
@@ -0,0 +1,176 @@
+package sff
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+// ethtoolHex formats b like "ethtool -m <if> hex on", one block per call
+func ethtoolHex(b []byte, offset int) string {
+	var s strings.Builder
+	s.WriteString("Offset\t\tValues\n------\t\t------")
+	for i, c := range b {
+		if i%16 == 0 {
+			fmt.Fprintf(&s, "\n0x%04x:\t\t", i+offset)
+		}
+		fmt.Fprintf(&s, "%02x ", c)
+	}
+	s.WriteString("\n")
+	return s.String()
+}
+
+// ethtoolHexPage formats upper page 'page' like
+// "ethtool -m <if> page <page> offset 128 length 128 hex on", with the
+// command line selecting the page
+func ethtoolHexPage(b []byte, page int) string {
+	return fmt.Sprintf("$ ethtool -m eth0 page %d offset 128 length 128 hex on\n", page) + ethtoolHex(b, 0x80)
+}
+
+// i2cdump formats a 256 byte table like "i2cdump -y <bus> <address>"
+func i2cdump(b []byte) string {
+	var s strings.Builder
+	s.WriteString("     0  1  2  3  4  5  6  7  8  9  a  b  c  d  e  f    0123456789abcdef\n")
+	for row := 0; row < 256; row += 16 {
+		fmt.Fprintf(&s, "%02x:", row)
+		for _, c := range b[row : row+16] {
+			fmt.Fprintf(&s, " %02x", c)
+		}
+		s.WriteString("    ")
+		for _, c := range b[row : row+16] {
+			if c < 0x20 || c > 0x7e {
+				c = '.'
+			}
+			s.WriteByte(c)
+		}
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+func TestParseDump(t *testing.T) {
+	sfp, err := os.ReadFile("testdata/FLEX-P.8596.02.bin")
+	if err != nil {
+		t.Fatalf("Failed to read EEPROM file: %v", err)
+	}
+	qsfp, err := os.ReadFile("testdata/TR-FC85S-N00.bin")
+	if err != nil {
+		t.Fatalf("Failed to read EEPROM file: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		dump string
+		want []byte
+	}{
+		{"binary", string(qsfp[:256]), qsfp[:256]},
+		{"ethtool hex", ethtoolHex(sfp, 0), sfp},
+		{"ethtool hex flat pages", ethtoolHex(qsfp[:128], 0) + "\n" + ethtoolHex(qsfp[128:256], 0x80) + "\n" + ethtoolHex(qsfp[256:384], 0x100), qsfp[:384]},
+		{"ethtool hex pages", ethtoolHex(qsfp[:128], 0) + "\n" + ethtoolHexPage(qsfp[128:256], 0) + "\n" + ethtoolHexPage(qsfp[256:384], 1), qsfp[:384]},
+		{"i2cdump SFP", i2cdump(sfp[:256]) + i2cdump(sfp[256:]), sfp},
+		{"i2cdump QSFP", i2cdump(qsfp[:256]), qsfp[:256]},
+		{"hex string", fmt.Sprintf("% x\n", qsfp), qsfp},
+		{"hex string compact", fmt.Sprintf("%x", sfp[:256]), sfp[:256]},
+		{"hex string prefixed", strings.ReplaceAll(fmt.Sprintf("%#x", qsfp[:256]), " ", ", "), qsfp[:256]},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := NewDumpReader([]byte(test.dump))
+			if err != nil {
+				t.Fatalf("NewDumpReader failed: %v", err)
+			}
+			if b, _ := r.Read(); !bytes.Equal(b, test.want) {
+				t.Errorf("Read() returned %d bytes, want %d bytes", len(b), len(test.want))
+			}
+			if _, err := ReadPaged(r); err != nil {
+				t.Errorf("ReadPaged failed: %v", err)
+			}
+		})
+	}
+
+	bad := sfp[:256]
+	errTests := []struct {
+		name string
+		dump string
+	}{
+		{"short binary", string(qsfp[:300])},
+		{"ethtool hex gap", strings.Replace(ethtoolHex(sfp, 0), "0x0010:", "0x0020:", 1)},
+		{"ethtool hex pages without page", ethtoolHex(qsfp[:128], 0) + ethtoolHex(qsfp[128:256], 0x80) + ethtoolHex(qsfp[256:384], 0x80)},
+		{"ethtool hex page twice", ethtoolHex(qsfp[:256], 0) + ethtoolHexPage(qsfp[256:384], 0)},
+		{"ethtool hex short page", ethtoolHex(qsfp[:256], 0) + ethtoolHexPage(qsfp[256:320], 3)},
+		{"ethtool hex no upper page 00h", ethtoolHex(qsfp[:128], 0) + ethtoolHexPage(qsfp[256:384], 3)},
+		{"i2cdump failed read", strings.Replace(i2cdump(bad), " 03 04", " XX 04", 1)},
+		{"i2cdump short table", strings.Join(strings.SplitN(i2cdump(bad), "\n", 10)[:9], "\n")},
+		{"i2cdump QSFP two tables", i2cdump(qsfp[:256]) + i2cdump(qsfp[256:])},
+		{"hex string odd", "03 04 0"},
+		{"empty", ""},
+	}
+	for _, test := range errTests {
+		if _, err := NewDumpReader([]byte(test.dump)); err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
+
+func TestParseDumpEthtoolSkippedPages(t *testing.T) {
+	qsfp, err := os.ReadFile("testdata/TR-FC85S-N00.bin")
+	if err != nil {
+		t.Fatalf("Failed to read EEPROM file: %v", err)
+	}
+	page03 := make([]byte, common.PageLen)
+	for i := range page03 {
+		page03[i] = byte(i + 1)
+	}
+	dump := ethtoolHex(qsfp[:128], 0) + "\n" + ethtoolHexPage(qsfp[128:256], 0) + "\n" + ethtoolHexPage(page03, 3)
+
+	r, err := NewDumpReader([]byte(dump))
+	if err != nil {
+		t.Fatalf("NewDumpReader failed: %v", err)
+	}
+	b, err := r.ReadRegion(common.UpperPage(common.AddressA0, 0, 3))
+	if err != nil {
+		t.Fatalf("ReadRegion(page 03h) failed: %v", err)
+	}
+	if !bytes.Equal(b, page03) {
+		t.Errorf("ReadRegion(page 03h) returned %x, want %x", b, page03)
+	}
+	for _, page := range []uint8{1, 2} {
+		if _, err := r.ReadRegion(common.UpperPage(common.AddressA0, 0, page)); err == nil {
+			t.Errorf("ReadRegion(page %02xh) returned no error for a page not in the dump", page)
+		}
+	}
+
+	// The flat layout has page 03h at its place, pages 01h-02h zero
+	want := append(append(append([]byte{}, qsfp[:256]...), make([]byte, 2*common.PageLen)...), page03...)
+	if b, _ := r.Read(); !bytes.Equal(b, want) {
+		t.Errorf("Read() returned %x, want %x", b, want)
+	}
+}
+
+func TestFileReaderShortDump(t *testing.T) {
+	b, err := os.ReadFile("testdata/IN-Q2AY2-35.bin")
+	if err != nil {
+		t.Fatalf("Failed to read EEPROM file: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "qsfp.bin")
+	if err := os.WriteFile(path, b[:256], 0644); err != nil {
+		t.Fatalf("Failed to write dump: %v", err)
+	}
+
+	r := NewFileReader(path)
+	if d, err := r.Read(); err != nil || len(d) != 256 {
+		t.Errorf("Read() = %d bytes, %v, want 256 bytes", len(d), err)
+	}
+	m, err := ReadPaged(r)
+	if err != nil {
+		t.Fatalf("ReadPaged failed: %v", err)
+	}
+	if m.Memory.Has(common.UpperPage(common.AddressA0, 0, 1)) || m.Sff8636.Page03 != nil {
+		t.Errorf("Pages beyond 00h present in a 256 byte dump: %v", m.Regions())
+	}
+}
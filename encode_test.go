@@ -0,0 +1,58 @@
+package sff
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncodeRoundTrip(t *testing.T) {
+	files, err := filepath.Glob("testdata/*.bin")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("No test files: %v", err)
+	}
+
+	for _, f := range files {
+		t.Run(filepath.Base(f), func(t *testing.T) {
+			b, err := os.ReadFile(f)
+			if err != nil {
+				t.Fatalf("Failed to read EEPROM file: %v", err)
+			}
+			var m Module
+			if err := m.UnmarshalBinary(b); err != nil {
+				t.Fatalf("UnmarshalBinary failed: %v", err)
+			}
+			got, err := m.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary failed: %v", err)
+			}
+			if !bytes.Equal(got, b) {
+				t.Errorf("Round trip differs, got %d bytes, want %d bytes", len(got), len(b))
+				for i := range b {
+					if i < len(got) && got[i] != b[i] {
+						t.Errorf("First difference at byte %d: 0x%02x != 0x%02x", i, got[i], b[i])
+						break
+					}
+				}
+			}
+
+			// Recomputing the check codes repairs a corrupted one
+			b[0x80+63] ^= 0xff
+			b[63] ^= 0xff
+			if err := m.UnmarshalBinary(b); err != nil {
+				t.Fatalf("UnmarshalBinary failed: %v", err)
+			}
+			fixed, err := m.Encode(true)
+			if err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if err := m.UnmarshalBinary(fixed); err != nil {
+				t.Fatalf("UnmarshalBinary failed: %v", err)
+			}
+			if err := m.Validate(); err != nil {
+				t.Errorf("Validate after Encode(true) = %v", err)
+			}
+		})
+	}
+}
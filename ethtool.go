@@ -0,0 +1,206 @@
+package sff
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+const (
+	siocEthtool = 0x8946
+
+	ethtoolGModuleInfo   = 0x00000042
+	ethtoolGModuleEeprom = 0x00000043
+
+	ifNameSize = 16
+)
+
+// Module types reported by ETHTOOL_GMODULEINFO
+const (
+	EthModuleSff8079 = 0x1
+	EthModuleSff8472 = 0x2
+	EthModuleSff8636 = 0x3
+	EthModuleSff8436 = 0x4
+
+	EthModuleSff8079Len    = 256
+	EthModuleSff8472Len    = 512
+	EthModuleSff8636Len    = 256
+	EthModuleSff8436Len    = 256
+	EthModuleSff8636MaxLen = 640
+	EthModuleSff8436MaxLen = 640
+)
+
+// struct ethtool_modinfo
+type ethtoolModInfo struct {
+	cmd       uint32
+	typ       uint32
+	eepromLen uint32
+	reserved  [8]uint32
+}
+
+// struct ethtool_eeprom, followed by the data
+type ethtoolEeprom struct {
+	cmd    uint32
+	magic  uint32
+	offset uint32
+	len    uint32
+}
+
+// struct ifreq with ifr_data
+type ifreqData struct {
+	name [ifNameSize]byte
+	data uintptr
+	_    [16]byte
+}
+
+// EthtoolReader implements Reader interface for modules behind a NIC driver,
+// using the SIOCETHTOOL ETHTOOL_GMODULEINFO/ETHTOOL_GMODULEEEPROM ioctls.
+type EthtoolReader struct {
+	ifname string
+}
+
+// NewEthtoolReader creates a new EthtoolReader for the given interface name
+func NewEthtoolReader(ifname string) *EthtoolReader {
+	return &EthtoolReader{ifname: ifname}
+}
+
+// ModuleInfo returns the module type and EEPROM length reported by the driver
+func (r *EthtoolReader) ModuleInfo() (uint32, int, error) {
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer syscall.Close(fd)
+
+	info, err := r.moduleInfo(fd)
+	if err != nil {
+		return 0, 0, err
+	}
+	return info.typ, int(info.eepromLen), nil
+}
+
+// Read implements the Reader interface for ethtool devices. It returns
+// exactly the EEPROM length reported by the driver, e.g. 256 bytes for a QSFP
+// module without upper pages 01h-03h.
+func (r *EthtoolReader) Read() ([]byte, error) {
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.Close(fd)
+
+	info, err := r.moduleInfo(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := r.readEeprom(fd, 0, int(info.eepromLen))
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// ReadRegion implements the PagedReader interface. The region is mapped onto
+// the flat layout the driver exposes for the module type.
+func (r *EthtoolReader) ReadRegion(region common.Region) ([]byte, error) {
+	if err := region.Validate(); err != nil {
+		return nil, err
+	}
+
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.Close(fd)
+
+	info, err := r.moduleInfo(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 0, region.Length)
+	for _, p := range region.Split() {
+		offset, err := ethtoolFlatOffset(info.typ, int(info.eepromLen), p)
+		if err != nil {
+			return nil, err
+		}
+		d, err := r.readEeprom(fd, offset, p.Length)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, d...)
+	}
+	return b, nil
+}
+
+// ethtoolFlatOffset returns the offset of a region, not crossing the half page
+// boundary, in the flat EEPROM of the given module type
+func ethtoolFlatOffset(typ uint32, eepromLen int, p common.Region) (int, error) {
+	offset := -1
+	switch typ {
+	case EthModuleSff8079, EthModuleSff8472:
+		if p.IsUpper() && (p.Bank != 0 || p.Page != 0) {
+			break
+		}
+		switch p.Address {
+		case common.AddressA0:
+			offset = p.Offset
+		case common.AddressA2:
+			offset = 256 + p.Offset
+		}
+	case EthModuleSff8636, EthModuleSff8436:
+		if p.Address != common.AddressA0 || p.Bank != 0 {
+			break
+		}
+		offset = p.Offset
+		if p.IsUpper() {
+			offset += int(p.Page) * common.PageLen
+		}
+	}
+	if offset < 0 || offset+p.Length > eepromLen {
+		return 0, fmt.Errorf("%s: %w", p, common.ErrRegionNotPresent)
+	}
+	return offset, nil
+}
+
+func (r *EthtoolReader) readEeprom(fd int, offset int, length int) ([]byte, error) {
+	hdr := int(unsafe.Sizeof(ethtoolEeprom{}))
+	buf := make([]byte, hdr+length)
+	ee := (*ethtoolEeprom)(unsafe.Pointer(&buf[0]))
+	ee.cmd = ethtoolGModuleEeprom
+	ee.offset = uint32(offset)
+	ee.len = uint32(length)
+	if err := r.ethtool(fd, unsafe.Pointer(&buf[0])); err != nil {
+		return nil, fmt.Errorf("%s: ETHTOOL_GMODULEEEPROM: %w", r.ifname, err)
+	}
+	return buf[hdr : hdr+length], nil
+}
+
+func (r *EthtoolReader) moduleInfo(fd int) (*ethtoolModInfo, error) {
+	info := &ethtoolModInfo{cmd: ethtoolGModuleInfo}
+	if err := r.ethtool(fd, unsafe.Pointer(info)); err != nil {
+		return nil, fmt.Errorf("%s: ETHTOOL_GMODULEINFO: %w", r.ifname, err)
+	}
+	if info.eepromLen == 0 {
+		return nil, fmt.Errorf("%s: module reports zero length eeprom", r.ifname)
+	}
+	return info, nil
+}
+
+func (r *EthtoolReader) ethtool(fd int, data unsafe.Pointer) error {
+	if len(r.ifname) >= ifNameSize {
+		return fmt.Errorf("interface name too long")
+	}
+	ifr := &ifreqData{data: uintptr(data)}
+	copy(ifr.name[:], r.ifname)
+	_, _, e1 := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), siocEthtool, uintptr(unsafe.Pointer(ifr)))
+	runtime.KeepAlive(data)
+	if e1 != 0 {
+		return e1
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package sff
 
 import (
+	"io"
 	"os"
 	"syscall"
 )
@@ -10,7 +11,7 @@ const (
 )
 
 type I2C struct {
-	rc *os.File
+	rc io.ReadWriteCloser
 }
 
 func NewI2C(path string, addr uint8) (*I2C, error) {
@@ -39,6 +40,35 @@ func (i2c *I2C) Read(p []byte) (int, error) {
 	return i2c.rc.Read(p)
 }
 
+// ReadAt sets the address pointer to offset and reads n bytes
+func (i2c *I2C) ReadAt(offset byte, n int) ([]byte, error) {
+	if err := i2c.WriteByte(offset); err != nil {
+		return nil, err
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(i2c.rc, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// WriteAt writes b starting at offset
+func (i2c *I2C) WriteAt(offset byte, b []byte) error {
+	_, err := i2c.Write(append([]byte{offset}, b...))
+	return err
+}
+
+// SelectPage selects the upper page using the page select byte (127), and
+// for banked memory (CMIS) the bank select byte (126)
+func (i2c *I2C) SelectPage(bank, page uint8) error {
+	if bank != 0 {
+		_, err := i2c.Write([]byte{126, bank, page})
+		return err
+	}
+	_, err := i2c.Write([]byte{127, page})
+	return err
+}
+
 func (i2c *I2C) Close() error {
 	return i2c.rc.Close()
 }
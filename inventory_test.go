@@ -0,0 +1,61 @@
+package sff
+
+import (
+	"os"
+	"testing"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+func TestInventory(t *testing.T) {
+	tests := []struct {
+		name  string
+		want  common.Inventory
+		reach common.Reach
+	}{
+		{"FLEX-P.8596.02", common.Inventory{
+			Identifier: common.IdentifierSfp, Vendor: "FLEXOPTIX", PartNumber: "P.8596.02", Revision: "A", SerialNumber: "F79D002",
+			Connector: common.ConnectorLc, BitRate: 10300, Wavelength: 850, LaneCount: 1, MediaType: common.MediaTypeMmf,
+		}, common.Reach{Om1: 20, Om2: 80, Om3: 300}},
+		{"JST01TMAC1CY5GEN", common.Inventory{
+			Identifier: common.IdentifierSfp, Vendor: "JDSU", PartNumber: "JST01TMAC1CY5GEN", Revision: "0000", SerialNumber: "FE385518002A",
+			Connector: common.ConnectorLc, BitRate: 10300, Wavelength: 1550, LaneCount: 1, MediaType: common.MediaTypeSmf,
+		}, common.Reach{Smf: 80000}},
+		{"TR-FC85S-N00", common.Inventory{
+			Identifier: common.IdentifierQsfp28, Vendor: "INNOLIGHT", PartNumber: "TR-FC85S-N00", Revision: "1A", SerialNumber: "INKAP3224117",
+			Connector: common.ConnectorMpo, BitRate: 25750, Wavelength: 850, LaneCount: 4, MediaType: common.MediaTypeMmf,
+		}, common.Reach{Om3: 70, Om4: 100}},
+		{"IN-Q2AY2-35", common.Inventory{
+			Identifier: common.IdentifierQsfp28, Vendor: "INPHI CORP", PartNumber: "IN-Q2AY2-35", Revision: "10", SerialNumber: "L202100651",
+			Connector: common.ConnectorLc, BitRate: 25750, Wavelength: 1549.3, LaneCount: 4, MediaType: common.MediaTypeSmf,
+		}, common.Reach{Smf: 80000}},
+		{"CMIS-QDD-400G-DR4", common.Inventory{
+			Identifier: common.IdentifierQsfpDd, Vendor: "GOSFF TEST", PartNumber: "QDD-400G-DR4", Revision: "A0", SerialNumber: "CMIS0001",
+			Connector: common.ConnectorMpo, Wavelength: 1311, LaneCount: 4, MediaType: common.MediaTypeSmf,
+		}, common.Reach{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := os.ReadFile("testdata/" + test.name + ".bin")
+			if err != nil {
+				t.Fatalf("Failed to read EEPROM file: %v", err)
+			}
+			m, err := Read(&MockReader{data: b})
+			if err != nil {
+				t.Fatalf("Failed to read module: %v", err)
+			}
+
+			var p common.InventoryProvider = m
+			got := p.Inventory()
+			if got.Reach != test.reach {
+				t.Errorf("Reach = %+v, want %+v", got.Reach, test.reach)
+			}
+			// OUI and date code are covered by the decoder tests
+			got.OUI, got.DateCode, got.Reach = common.VendorOUI{}, common.DateCode{}, common.Reach{}
+			if got != test.want {
+				t.Errorf("Inventory() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
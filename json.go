@@ -0,0 +1,106 @@
+package sff
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bluecmd/go-sff/cmis"
+	"github.com/bluecmd/go-sff/sff8079"
+	"github.com/bluecmd/go-sff/sff8636"
+)
+
+// moduleJSON is the JSON layout of Module. Type selects which of the decoded
+// models is set, Eeprom holds the encoded dump in hex.
+type moduleJSON struct {
+	Type    Type             `json:"type"`
+	Eeprom  string           `json:"eeprom,omitempty"`
+	Sff8079 *sff8079.Sff8079 `json:"sff8079,omitempty"`
+	Sff8636 *sff8636.Sff8636 `json:"sff8636,omitempty"`
+	Cmis    *cmis.Cmis       `json:"cmis,omitempty"`
+}
+
+// MarshalJSON encodes the module type, the decoded model of that type and the
+// EEPROM dump, see Encode. Returns the error of Encode if the module cannot be
+// encoded, as the JSON could not be read back otherwise.
+func (m *Module) MarshalJSON() ([]byte, error) {
+	j := moduleJSON{Type: m.Type}
+	switch m.Type {
+	case TypeSff8079:
+		j.Sff8079 = m.Sff8079
+	case TypeSff8636:
+		j.Sff8636 = m.Sff8636
+	case TypeCmis:
+		j.Cmis = m.Cmis
+	default:
+		return json.Marshal(j)
+	}
+	b, err := m.Encode(false)
+	if err != nil {
+		return nil, fmt.Errorf("encoding eeprom: %w", err)
+	}
+	j.Eeprom = hex.EncodeToString(b)
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes the output of MarshalJSON. The EEPROM dump is decoded
+// first, so bytes not represented in JSON are kept and newer versions of the
+// decoders apply, then the fields of the model are applied on top. Edited
+// fields therefore take precedence over the dump.
+func (m *Module) UnmarshalJSON(in []byte) error {
+	var j struct {
+		Type    Type            `json:"type"`
+		Eeprom  string          `json:"eeprom"`
+		Sff8079 json.RawMessage `json:"sff8079"`
+		Sff8636 json.RawMessage `json:"sff8636"`
+		Cmis    json.RawMessage `json:"cmis"`
+	}
+	if err := json.Unmarshal(in, &j); err != nil {
+		return err
+	}
+
+	d := &Module{Type: j.Type}
+	if j.Eeprom != "" {
+		b, err := hex.DecodeString(j.Eeprom)
+		if err != nil {
+			return fmt.Errorf("decoding eeprom: %w", err)
+		}
+		if d, err = Decode(MemoryFromFlat(b)); err != nil {
+			return fmt.Errorf("decoding eeprom: %w", err)
+		}
+		if d.Type != j.Type {
+			return fmt.Errorf("eeprom holds a %s module, want %s", d.Type, j.Type)
+		}
+	}
+
+	var model interface{}
+	var fields json.RawMessage
+	switch j.Type {
+	case TypeSff8079:
+		if d.Sff8079 == nil {
+			d.Sff8079 = &sff8079.Sff8079{}
+		}
+		model, fields = d.Sff8079, j.Sff8079
+	case TypeSff8636:
+		if d.Sff8636 == nil {
+			d.Sff8636 = &sff8636.Sff8636{}
+		}
+		model, fields = d.Sff8636, j.Sff8636
+	case TypeCmis:
+		if d.Cmis == nil {
+			d.Cmis = &cmis.Cmis{}
+		}
+		model, fields = d.Cmis, j.Cmis
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownType, j.Type)
+	}
+	if fields == nil {
+		return fmt.Errorf("missing %s model", j.Type)
+	}
+	if err := json.Unmarshal(fields, model); err != nil {
+		return err
+	}
+
+	*m = *d
+	return nil
+}
@@ -0,0 +1,112 @@
+package sff
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bluecmd/go-sff/sff8079"
+)
+
+func TestJSONGolden(t *testing.T) {
+	files, err := filepath.Glob("testdata/*.bin")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("No test files: %v", err)
+	}
+
+	for _, binFile := range files {
+		name := strings.TrimSuffix(filepath.Base(binFile), ".bin")
+		t.Run(name, func(t *testing.T) {
+			eeprom, err := os.ReadFile(binFile)
+			if err != nil {
+				t.Fatalf("Failed to read EEPROM file %s: %v", binFile, err)
+			}
+			module, err := Read(&MockReader{data: eeprom})
+			if err != nil {
+				t.Fatalf("Failed to parse EEPROM data: %v", err)
+			}
+			actual, err := json.MarshalIndent(module, "", "  ")
+			if err != nil {
+				t.Fatalf("Failed to marshal JSON: %v", err)
+			}
+			actual = append(actual, '\n')
+
+			// Check if golden output file exists, if not create it
+			jsonFile := "testdata/" + name + ".json"
+			if _, err := os.Stat(jsonFile); os.IsNotExist(err) {
+				if err := os.WriteFile(jsonFile, actual, 0644); err != nil {
+					t.Fatalf("Failed to create golden output file %s: %v", jsonFile, err)
+				}
+				t.Logf("Created golden output file: %s", jsonFile)
+			}
+			expected, err := os.ReadFile(jsonFile)
+			if err != nil {
+				t.Fatalf("Failed to read expected output file %s: %v", jsonFile, err)
+			}
+			if !bytes.Equal(actual, expected) {
+				t.Errorf("JSON output mismatch for %s", name)
+			}
+
+			// The golden file decodes to the same module
+			var m Module
+			if err := json.Unmarshal(expected, &m); err != nil {
+				t.Fatalf("Failed to unmarshal JSON: %v", err)
+			}
+			if m.Type != module.Type || m.String() != module.String() {
+				t.Errorf("Unmarshaled module differs:\n%s", m.String())
+			}
+			if b, err := m.MarshalBinary(); err != nil || !bytes.Equal(b, eeprom) {
+				t.Errorf("Unmarshaled module does not encode to the EEPROM data: %v", err)
+			}
+			again, _ := json.MarshalIndent(&m, "", "  ")
+			if !bytes.Equal(append(again, '\n'), expected) {
+				t.Error("JSON of the unmarshaled module differs")
+			}
+		})
+	}
+}
+
+func TestJSONEdit(t *testing.T) {
+	module := readTestModule(t, "TR-FC85S-N00")
+	in, err := json.Marshal(module)
+	if err != nil {
+		t.Fatalf("Failed to marshal JSON: %v", err)
+	}
+
+	// Edited fields take precedence over the EEPROM dump
+	in = bytes.Replace(in, []byte(`"vendorSn":{"hex":"494e4b41503332323431313720202020"`), []byte(`"vendorSn":{"hex":"4c414230312020202020202020202020"`), 1)
+	var m Module
+	if err := json.Unmarshal(in, &m); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+	if sn := m.Inventory().SerialNumber; sn != "LAB01" {
+		t.Errorf("SerialNumber = %q, want LAB01", sn)
+	}
+
+	// Without the dump only the fields in JSON are decoded
+	var noDump map[string]interface{}
+	json.Unmarshal(in, &noDump)
+	delete(noDump, "eeprom")
+	in, _ = json.Marshal(noDump)
+	if err := json.Unmarshal(in, &m); err != nil {
+		t.Fatalf("Failed to unmarshal JSON without eeprom: %v", err)
+	}
+	if inv := m.Inventory(); inv.PartNumber != "TR-FC85S-N00" || inv.SerialNumber != "LAB01" {
+		t.Errorf("Unexpected inventory %+v", inv)
+	}
+
+	for _, in := range []string{`{"type":"Unknown"}`, `{"type":"SFF-8079"}`, `{"type":"CMIS","eeprom":"zz","cmis":{}}`} {
+		if err := json.Unmarshal([]byte(in), &m); err == nil {
+			t.Errorf("Expected error for %s", in)
+		}
+	}
+
+	// Modules that cannot be encoded would not read back
+	ext := &Module{Type: TypeSff8079, Sff8079: &sff8079.Sff8079{CalibrationMode: sff8079.CalibrationExternal}}
+	if _, err := json.Marshal(ext); err == nil {
+		t.Error("Expected error for a module that cannot be encoded")
+	}
+}
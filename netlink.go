@@ -0,0 +1,296 @@
+package sff
+
+import (
+	"encoding/binary"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+const (
+	nlmsgHdrLen  = 16
+	genlHdrLen   = 4
+	nlattrHdrLen = 4
+
+	nlmsgError = 0x2
+	nlmsgDone  = 0x3
+
+	nlmFRequest = 0x1
+
+	genlIdCtrl         = 0x10
+	ctrlCmdGetFamily   = 3
+	ctrlAttrFamilyId   = 1
+	ctrlAttrFamilyName = 2
+
+	ethtoolGenlName    = "ethtool"
+	ethtoolGenlVersion = 1
+
+	ethtoolMsgModuleEepromGet = 31
+
+	ethtoolAHeaderDevName = 2
+
+	ethtoolAModuleEepromHeader     = 1
+	ethtoolAModuleEepromOffset     = 2
+	ethtoolAModuleEepromLength     = 3
+	ethtoolAModuleEepromPage       = 4
+	ethtoolAModuleEepromBank       = 5
+	ethtoolAModuleEepromI2cAddress = 6
+	ethtoolAModuleEepromData       = 7
+
+	nlaFNested = 0x8000
+
+	// Size of one half page as accepted by ETHTOOL_MSG_MODULE_EEPROM_GET
+	eepromPageLen = 128
+)
+
+// Netlink messages are in host byte order
+var nativeEndian binary.ByteOrder = func() binary.ByteOrder {
+	x := uint16(1)
+	if *(*byte)(unsafe.Pointer(&x)) == 1 {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}()
+
+// netlinkConn is a datagram oriented generic netlink socket
+type netlinkConn interface {
+	Send(msg []byte) error
+	Receive() ([]byte, error)
+	Close() error
+}
+
+// NetlinkReader implements Reader interface using the ethtool generic netlink
+// family (ETHTOOL_MSG_MODULE_EEPROM_GET). Unlike the ioctl based EthtoolReader
+// it can address arbitrary pages, banks and I2C addresses of the module.
+type NetlinkReader struct {
+	ifname string
+	dial   func() (netlinkConn, error)
+	conn   netlinkConn
+	family uint16
+	seq    uint32
+}
+
+// NewNetlinkReader creates a new NetlinkReader for the given interface name
+func NewNetlinkReader(ifname string) *NetlinkReader {
+	return &NetlinkReader{ifname: ifname, dial: dialNetlink}
+}
+
+// Close closes the underlying netlink socket
+func (r *NetlinkReader) Close() error {
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	r.conn = nil
+	r.family = 0
+	return err
+}
+
+// ReadPage reads length bytes starting at offset (0-255) of the given I2C
+// address. Offsets 128 and above address the upper page selected by bank and
+// page, offsets below 128 always address the lower page.
+func (r *NetlinkReader) ReadPage(address, bank, page uint8, offset, length int) ([]byte, error) {
+	if offset < 0 || length < 0 || offset+length > 2*eepromPageLen {
+		return nil, fmt.Errorf("invalid eeprom region: offset %d length %d", offset, length)
+	}
+
+	b := make([]byte, 0, length)
+	for length > 0 {
+		// The kernel refuses requests crossing the half page boundary
+		n := length
+		if offset < eepromPageLen && offset+n > eepromPageLen {
+			n = eepromPageLen - offset
+		}
+		p, k := page, bank
+		if offset < eepromPageLen {
+			p, k = 0, 0
+		}
+		d, err := r.request(address, k, p, offset, n)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, d...)
+		offset += n
+		length -= n
+	}
+	return b, nil
+}
+
+// ReadRegion implements the PagedReader interface
+func (r *NetlinkReader) ReadRegion(region common.Region) ([]byte, error) {
+	if err := region.Validate(); err != nil {
+		return nil, err
+	}
+	return r.ReadPage(region.Address, region.Bank, region.Page, region.Offset, region.Length)
+}
+
+// Read implements the Reader interface. It returns the same layout as
+// `ethtool -m raw on`: A0h followed by A2h for SFP modules, and the lower
+// page followed by upper pages 00h-03h for paged SFF-8636 modules, and the
+// common.FlatCmis layout for CMIS modules.
+func (r *NetlinkReader) Read() ([]byte, error) {
+	m, err := ReadMemory(r)
+	if err != nil {
+		return nil, err
+	}
+	return flatMemory(m), nil
+}
+
+func (r *NetlinkReader) open() error {
+	if r.conn != nil {
+		return nil
+	}
+	c, err := r.dial()
+	if err != nil {
+		return err
+	}
+	r.conn = c
+
+	msg := r.message(genlIdCtrl, ctrlCmdGetFamily, 1,
+		nlattr(ctrlAttrFamilyName, append([]byte(ethtoolGenlName), 0)))
+	attrs, err := r.execute(msg)
+	if err != nil {
+		r.Close()
+		return fmt.Errorf("resolving %s netlink family: %w", ethtoolGenlName, err)
+	}
+	id, ok := attrs[ctrlAttrFamilyId]
+	if !ok || len(id) < 2 {
+		r.Close()
+		return fmt.Errorf("resolving %s netlink family: no family id in reply", ethtoolGenlName)
+	}
+	r.family = nativeEndian.Uint16(id)
+	return nil
+}
+
+func (r *NetlinkReader) request(address, bank, page uint8, offset, length int) ([]byte, error) {
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+
+	hdr := nlattr(ethtoolAHeaderDevName, append([]byte(r.ifname), 0))
+	msg := r.message(r.family, ethtoolMsgModuleEepromGet, ethtoolGenlVersion,
+		nlattr(ethtoolAModuleEepromHeader|nlaFNested, hdr),
+		nlattrU32(ethtoolAModuleEepromOffset, uint32(offset)),
+		nlattrU32(ethtoolAModuleEepromLength, uint32(length)),
+		nlattr(ethtoolAModuleEepromPage, []byte{page}),
+		nlattr(ethtoolAModuleEepromBank, []byte{bank}),
+		nlattr(ethtoolAModuleEepromI2cAddress, []byte{address}))
+
+	attrs, err := r.execute(msg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: reading eeprom 0x%02x bank %d page %d offset %d: %w", r.ifname, address, bank, page, offset, err)
+	}
+	data, ok := attrs[ethtoolAModuleEepromData]
+	if !ok {
+		return nil, fmt.Errorf("%s: no eeprom data in reply", r.ifname)
+	}
+	if len(data) != length {
+		return nil, fmt.Errorf("%s: short eeprom read, got %d bytes want %d", r.ifname, len(data), length)
+	}
+	return data, nil
+}
+
+// message builds a generic netlink request message
+func (r *NetlinkReader) message(family uint16, cmd uint8, version uint8, attrs ...[]byte) []byte {
+	r.seq++
+	b := make([]byte, nlmsgHdrLen+genlHdrLen)
+	for _, a := range attrs {
+		b = append(b, a...)
+	}
+	nativeEndian.PutUint32(b[0:4], uint32(len(b)))
+	nativeEndian.PutUint16(b[4:6], family)
+	nativeEndian.PutUint16(b[6:8], nlmFRequest)
+	nativeEndian.PutUint32(b[8:12], r.seq)
+	b[16] = cmd
+	b[17] = version
+	return b
+}
+
+// execute sends msg and returns the attributes of the matching reply
+func (r *NetlinkReader) execute(msg []byte) (map[uint16][]byte, error) {
+	if err := r.conn.Send(msg); err != nil {
+		return nil, err
+	}
+	seq := nativeEndian.Uint32(msg[8:12])
+
+	for {
+		b, err := r.conn.Receive()
+		if err != nil {
+			return nil, err
+		}
+		for len(b) >= nlmsgHdrLen {
+			l := int(nativeEndian.Uint32(b[0:4]))
+			if l < nlmsgHdrLen || l > len(b) {
+				return nil, fmt.Errorf("malformed netlink message")
+			}
+			typ := nativeEndian.Uint16(b[4:6])
+			s := nativeEndian.Uint32(b[8:12])
+			payload := b[nlmsgHdrLen:l]
+			if nlmsgAlign(l) < len(b) {
+				b = b[nlmsgAlign(l):]
+			} else {
+				b = nil
+			}
+			if s != seq {
+				continue
+			}
+			switch typ {
+			case nlmsgError:
+				if len(payload) < 4 {
+					return nil, fmt.Errorf("malformed netlink error")
+				}
+				code := int32(nativeEndian.Uint32(payload[0:4]))
+				if code == 0 {
+					continue
+				}
+				return nil, syscall.Errno(-code)
+			case nlmsgDone:
+				return nil, fmt.Errorf("unexpected end of netlink dump")
+			}
+			if len(payload) < genlHdrLen {
+				return nil, fmt.Errorf("malformed generic netlink message")
+			}
+			return parseNlattrs(payload[genlHdrLen:]), nil
+		}
+	}
+}
+
+func nlmsgAlign(l int) int {
+	return (l + 3) &^ 3
+}
+
+func nlattr(typ uint16, data []byte) []byte {
+	l := nlattrHdrLen + len(data)
+	b := make([]byte, nlmsgAlign(l))
+	nativeEndian.PutUint16(b[0:2], uint16(l))
+	nativeEndian.PutUint16(b[2:4], typ)
+	copy(b[nlattrHdrLen:], data)
+	return b
+}
+
+func nlattrU32(typ uint16, v uint32) []byte {
+	b := make([]byte, 4)
+	nativeEndian.PutUint32(b, v)
+	return nlattr(typ, b)
+}
+
+// parseNlattrs parses a stream of netlink attributes, keyed by type with the
+// nested and byte order flags masked out
+func parseNlattrs(b []byte) map[uint16][]byte {
+	attrs := map[uint16][]byte{}
+	for len(b) >= nlattrHdrLen {
+		l := int(nativeEndian.Uint16(b[0:2]))
+		if l < nlattrHdrLen || l > len(b) {
+			break
+		}
+		typ := nativeEndian.Uint16(b[2:4]) & 0x3fff
+		attrs[typ] = b[nlattrHdrLen:l]
+		if nlmsgAlign(l) > len(b) {
+			break
+		}
+		b = b[nlmsgAlign(l):]
+	}
+	return attrs
+}
@@ -0,0 +1,40 @@
+package sff
+
+import (
+	"syscall"
+)
+
+const netlinkGeneric = 16
+
+type netlinkSocket struct {
+	fd int
+}
+
+func dialNetlink() (netlinkConn, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW|syscall.SOCK_CLOEXEC, netlinkGeneric)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+	return &netlinkSocket{fd: fd}, nil
+}
+
+func (s *netlinkSocket) Send(msg []byte) error {
+	return syscall.Sendto(s.fd, msg, 0, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK})
+}
+
+func (s *netlinkSocket) Receive() ([]byte, error) {
+	b := make([]byte, 65536)
+	n, _, err := syscall.Recvfrom(s.fd, b, 0)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+
+func (s *netlinkSocket) Close() error {
+	return syscall.Close(s.fd)
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package sff
+
+import (
+	"errors"
+)
+
+func dialNetlink() (netlinkConn, error) {
+	return nil, errors.New("ethtool netlink is only supported on linux")
+}
@@ -0,0 +1,169 @@
+package sff
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+)
+
+const fakeEthtoolFamily = 0x1a
+
+// fakeNetlink implements netlinkConn on top of a flat EEPROM image laid out
+// like `ethtool -m raw on` output
+type fakeNetlink struct {
+	image    []byte
+	requests [][4]int // address, page, offset, length
+	replies  [][]byte
+	closed   bool
+}
+
+func (f *fakeNetlink) Send(msg []byte) error {
+	typ := nativeEndian.Uint16(msg[4:6])
+	seq := nativeEndian.Uint32(msg[8:12])
+	attrs := parseNlattrs(msg[nlmsgHdrLen+genlHdrLen:])
+
+	switch typ {
+	case genlIdCtrl:
+		if !bytes.Equal(attrs[ctrlAttrFamilyName], []byte("ethtool\x00")) {
+			f.replies = append(f.replies, fakeNetlinkError(seq, syscall.ENOENT))
+			return nil
+		}
+		id := make([]byte, 2)
+		nativeEndian.PutUint16(id, fakeEthtoolFamily)
+		f.replies = append(f.replies, fakeNetlinkReply(seq, genlIdCtrl, nlattr(ctrlAttrFamilyId, id)))
+	case fakeEthtoolFamily:
+		address := int(attrs[ethtoolAModuleEepromI2cAddress][0])
+		page := int(attrs[ethtoolAModuleEepromPage][0])
+		offset := int(nativeEndian.Uint32(attrs[ethtoolAModuleEepromOffset]))
+		length := int(nativeEndian.Uint32(attrs[ethtoolAModuleEepromLength]))
+		f.requests = append(f.requests, [4]int{address, page, offset, length})
+
+		if offset < 128 && offset+length > 128 {
+			f.replies = append(f.replies, fakeNetlinkError(seq, syscall.EINVAL))
+			return nil
+		}
+
+		base := 0
+		switch {
+		case address == 0x51:
+			base = 256
+		case offset >= 128:
+			base = page * 128
+		}
+		if base+offset+length > len(f.image) {
+			f.replies = append(f.replies, fakeNetlinkError(seq, syscall.EIO))
+			return nil
+		}
+		data := f.image[base+offset : base+offset+length]
+		f.replies = append(f.replies, fakeNetlinkReply(seq, fakeEthtoolFamily, nlattr(ethtoolAModuleEepromData, data)))
+	default:
+		f.replies = append(f.replies, fakeNetlinkError(seq, syscall.EOPNOTSUPP))
+	}
+	return nil
+}
+
+func (f *fakeNetlink) Receive() ([]byte, error) {
+	if len(f.replies) == 0 {
+		return nil, errors.New("no pending reply")
+	}
+	r := f.replies[0]
+	f.replies = f.replies[1:]
+	return r, nil
+}
+
+func (f *fakeNetlink) Close() error {
+	f.closed = true
+	return nil
+}
+
+func fakeNetlinkReply(seq uint32, family uint16, attrs ...[]byte) []byte {
+	r := &NetlinkReader{seq: seq - 1}
+	return r.message(family, 0, 1, attrs...)
+}
+
+func fakeNetlinkError(seq uint32, errno syscall.Errno) []byte {
+	b := make([]byte, nlmsgHdrLen+4)
+	nativeEndian.PutUint32(b[0:4], uint32(len(b)))
+	nativeEndian.PutUint16(b[4:6], nlmsgError)
+	nativeEndian.PutUint32(b[8:12], seq)
+	nativeEndian.PutUint32(b[16:20], uint32(-int32(errno)))
+	return b
+}
+
+func newFakeNetlinkReader(image []byte) (*NetlinkReader, *fakeNetlink) {
+	// The fake module exposes the image padded to at least 640 bytes
+	f := &fakeNetlink{image: make([]byte, 640)}
+	if len(image) > len(f.image) {
+		f.image = make([]byte, len(image))
+	}
+	copy(f.image, image)
+	r := NewNetlinkReader("eth0")
+	r.dial = func() (netlinkConn, error) { return f, nil }
+	return r, f
+}
+
+func TestNetlinkReadPage(t *testing.T) {
+	image := make([]byte, 640)
+	for i := range image {
+		image[i] = byte(i / 128)
+	}
+	r, f := newFakeNetlinkReader(image)
+	defer r.Close()
+
+	// Crossing the half page boundary is split into two requests
+	b, err := r.ReadPage(0x50, 0, 3, 120, 16)
+	if err != nil {
+		t.Fatalf("ReadPage failed: %v", err)
+	}
+	want := append(bytes.Repeat([]byte{0}, 8), bytes.Repeat([]byte{4}, 8)...)
+	if !bytes.Equal(b, want) {
+		t.Errorf("ReadPage() = % x, want % x", b, want)
+	}
+	if len(f.requests) != 2 || f.requests[0] != [4]int{0x50, 0, 120, 8} || f.requests[1] != [4]int{0x50, 3, 128, 8} {
+		t.Errorf("Unexpected requests %v", f.requests)
+	}
+
+	if _, err := r.ReadPage(0x50, 0, 0, 200, 100); err == nil {
+		t.Error("Expected error for region beyond 256 bytes")
+	}
+}
+
+func TestNetlinkReadError(t *testing.T) {
+	r, _ := newFakeNetlinkReader(make([]byte, 256))
+	defer r.Close()
+
+	_, err := r.ReadPage(0x50, 0, 4, 128, 128)
+	if !errors.Is(err, syscall.EIO) {
+		t.Errorf("Expected EIO, got %v", err)
+	}
+}
+
+func TestNetlinkRead(t *testing.T) {
+	for _, name := range []string{"FLEX-P.8596.02", "IN-Q2AY2-35"} {
+		t.Run(name, func(t *testing.T) {
+			image, err := os.ReadFile("testdata/" + name + ".bin")
+			if err != nil {
+				t.Fatalf("Failed to read EEPROM file: %v", err)
+			}
+			r, f := newFakeNetlinkReader(image)
+
+			module, err := Read(r)
+			if err != nil {
+				t.Fatalf("Read failed: %v", err)
+			}
+			want, err := Read(&MockReader{data: image})
+			if err != nil {
+				t.Fatalf("Read failed: %v", err)
+			}
+			if module.String() != want.String() {
+				t.Errorf("Netlink read differs from file:\n%s\nwant:\n%s", module.String(), want.String())
+			}
+
+			if err := r.Close(); err != nil || !f.closed {
+				t.Errorf("Close() = %v, closed %t", err, f.closed)
+			}
+		})
+	}
+}
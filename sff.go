@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/bluecmd/go-sff/cmis"
+	"github.com/bluecmd/go-sff/common"
 	"github.com/bluecmd/go-sff/sff8079"
 	"github.com/bluecmd/go-sff/sff8636"
 )
@@ -14,6 +16,13 @@ type Reader interface {
 	Read() ([]byte, error)
 }
 
+// PagedReader interface defines how to read a region of SFF module memory.
+// Implementations return an error wrapping common.ErrRegionNotPresent for
+// regions the module or dump does not provide.
+type PagedReader interface {
+	ReadRegion(r common.Region) ([]byte, error)
+}
+
 // Type of eeprom module.
 type Type string
 
@@ -21,6 +30,7 @@ const (
 	TypeUnknown = Type("Unknown")
 	TypeSff8079 = Type("SFF-8079")
 	TypeSff8636 = Type("SFF-8636")
+	TypeCmis    = Type("CMIS")
 )
 
 var ErrUnknownType = errors.New("unknown type")
@@ -29,6 +39,8 @@ type Module struct {
 	Type Type
 	*sff8079.Sff8079
 	*sff8636.Sff8636
+	*cmis.Cmis
+	Memory *common.Memory `json:"-"`
 }
 
 func (m *Module) String() string {
@@ -37,6 +49,8 @@ func (m *Module) String() string {
 		return m.Sff8079.String()
 	case TypeSff8636:
 		return m.Sff8636.String()
+	case TypeCmis:
+		return m.Cmis.String()
 	}
 	return ""
 }
@@ -47,23 +61,178 @@ func (m *Module) StringCol() string {
 		return m.Sff8079.StringCol()
 	case TypeSff8636:
 		return m.Sff8636.StringCol()
+	case TypeCmis:
+		return m.Cmis.StringCol()
 	}
 	return ""
 }
 
-func GetType(eeprom []byte) (Type, error) {
-	if len(eeprom) < 512 {
-		return TypeUnknown, fmt.Errorf("eeprom size to small needs to be 512 bytes or larger got: %d bytes", len(eeprom))
+// EthtoolString renders the module like "ethtool -m", see
+// sff8079.Sff8079.EthtoolString and sff8636.Sff8636.EthtoolString. Returns an
+// error wrapping ErrNotSupported for CMIS modules.
+func (m *Module) EthtoolString() (string, error) {
+	switch m.Type {
+	case TypeSff8079:
+		return m.Sff8079.EthtoolString(), nil
+	case TypeSff8636:
+		return m.Sff8636.EthtoolString(), nil
+	case TypeCmis:
+		return "", fmt.Errorf("ethtool output of %s modules: %w", m.Type, ErrNotSupported)
+	}
+	return "", ErrUnknownType
+}
+
+// Validate verifies the module check codes, see sff8079.Sff8079.Validate,
+// sff8636.Sff8636.Validate and cmis.Cmis.Validate
+func (m *Module) Validate() error {
+	switch m.Type {
+	case TypeSff8079:
+		return m.Sff8079.Validate()
+	case TypeSff8636:
+		return m.Sff8636.Validate()
+	case TypeCmis:
+		return m.Cmis.Validate()
+	}
+	return ErrUnknownType
+}
+
+// Encode returns the flat EEPROM dump of the module, see sff8079.Sff8079.Encode,
+// sff8636.Sff8636.Encode and cmis.Cmis.Encode
+func (m *Module) Encode(updateChecksums bool) ([]byte, error) {
+	switch m.Type {
+	case TypeSff8079:
+		return m.Sff8079.Encode(updateChecksums)
+	case TypeSff8636:
+		return m.Sff8636.Encode(updateChecksums)
+	case TypeCmis:
+		return m.Cmis.Encode(updateChecksums)
+	}
+	return nil, ErrUnknownType
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, see Encode
+func (m *Module) MarshalBinary() ([]byte, error) {
+	return m.Encode(false)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for flat dumps, see
+// MemoryFromFlat
+func (m *Module) UnmarshalBinary(b []byte) error {
+	d, err := Decode(MemoryFromFlat(b))
+	if err != nil {
+		return err
+	}
+	*m = *d
+	return nil
+}
+
+// Inventory returns the vendor independent identification of the module, see
+// common.Inventory. Module implements common.InventoryProvider for all module
+// types.
+func (m *Module) Inventory() common.Inventory {
+	switch m.Type {
+	case TypeSff8079:
+		return m.Sff8079.Inventory()
+	case TypeSff8636:
+		return m.Sff8636.Inventory()
+	case TypeCmis:
+		return m.Cmis.Inventory()
+	}
+	return common.Inventory{}
+}
+
+// Diagnostics returns the per-lane diagnostic monitors of SFP and QSFP
+// modules, see common.Diagnostics. Returns nil for other module types, for
+// SFP modules without digital diagnostics or A2h and for QSFP modules that
+// advertise no monitors.
+func (m *Module) Diagnostics() *common.Diagnostics {
+	switch m.Type {
+	case TypeSff8079:
+		return m.Sff8079.Diagnostics()
+	case TypeSff8636:
+		return m.Sff8636.Diagnostics()
+	}
+	return nil
+}
+
+// Regions returns the half pages of module memory that were read, in the
+// order of common.Memory.Regions
+func (m *Module) Regions() []common.Region {
+	if m.Memory == nil {
+		return nil
+	}
+	return m.Memory.Regions()
+}
+
+// monitorRegions lists the real time diagnostic monitors per module type
+var monitorRegions = map[Type]common.Region{
+	TypeSff8079: {Address: common.AddressA2, Offset: 96, Length: 16},
+	TypeSff8636: {Address: common.AddressA0, Offset: 22, Length: 36},
+}
+
+// Refresh re-reads the real time diagnostic monitors of SFP (A2h bytes
+// 96-111) and QSFP (bytes 22-57) modules and decodes the module again. All
+// other memory, including the latched flags, keeps the value of the previous
+// read.
+func (m *Module) Refresh(reader PagedReader) error {
+	region, ok := monitorRegions[m.Type]
+	if !ok || m.Memory == nil {
+		return fmt.Errorf("refreshing %s modules is not supported", m.Type)
+	}
+	if m.Diagnostics() == nil {
+		return fmt.Errorf("module does not implement digital diagnostics")
+	}
+	b, err := reader.ReadRegion(region)
+	if err != nil {
+		return err
+	}
+	if err := m.Memory.Write(region, b); err != nil {
+		return err
 	}
+	n, err := Decode(m.Memory)
+	if err != nil {
+		return err
+	}
+	*m = *n
+	return nil
+}
 
+func isSff8079(id byte) bool {
 	// 0xB is "DWDM-SFP/SFP+ (not using SFF-8472)" so technically it shouldn't be
 	// compatible with SFF-8079 but in reality it seems to be.
-	if (eeprom[0] == 2 || eeprom[0] == 3 || eeprom[0] == 0xb) && eeprom[1] == 4 {
+	return id == 2 || id == 3 || id == 0xb
+}
+
+func isSff8636(id byte) bool {
+	return id == 12 || id == 13 || id == 17
+}
+
+// GetType detects the module type of a flat EEPROM dump of at least the A0h
+// lower page and upper page 00h
+func GetType(eeprom []byte) (Type, error) {
+	if len(eeprom) < 2*common.PageLen {
+		return TypeUnknown, fmt.Errorf("eeprom size to small needs to be %d bytes or larger got: %d bytes", 2*common.PageLen, len(eeprom))
+	}
+	return GetMemoryType(MemoryFromFlat(eeprom))
+}
+
+// GetMemoryType detects the module type from A0h lower page and upper page 00h
+func GetMemoryType(m *common.Memory) (Type, error) {
+	a0, err := m.Read(common.Region{Address: common.AddressA0, Offset: 0, Length: 2 * common.PageLen})
+	if err != nil {
+		return TypeUnknown, err
+	}
+
+	if isSff8079(a0[0]) && a0[1] == 4 {
 		return TypeSff8079, nil
 	}
 
-	if eeprom[128] == 12 || eeprom[128] == 13 || eeprom[128] == 17 {
-		if eeprom[127] != 0 {
+	if cmis.IsCmis(a0[0]) {
+		return TypeCmis, nil
+	}
+
+	if isSff8636(a0[128]) {
+		if a0[127] != 0 {
 			return TypeSff8636, fmt.Errorf("upper page is not 00h")
 		}
 		return TypeSff8636, nil
@@ -72,30 +241,183 @@ func GetType(eeprom []byte) (Type, error) {
 	return TypeUnknown, fmt.Errorf("eeprom unknown type")
 }
 
+// MemoryFromFlat converts a flat EEPROM dump to paged memory, using the SFP
+// layout (A0h followed by A2h) for SFP identifiers, the CMIS layout for CMIS
+// identifiers and the ethtool SFF-8636 layout (lower page followed by upper
+// pages) otherwise
+func MemoryFromFlat(eeprom []byte) *common.Memory {
+	if len(eeprom) > 0 && isSff8079(eeprom[0]) {
+		return common.MemoryFromFlatSff8079(eeprom)
+	}
+	if len(eeprom) > 0 && cmis.IsCmis(eeprom[0]) {
+		return common.MemoryFromFlatCmis(eeprom)
+	}
+	return common.MemoryFromFlatSff8636(eeprom)
+}
+
+// flatPages lists the upper pages following upper page 00h in the flat
+// SFF-8636 and CMIS layouts
+var flatPages = map[Type][]uint8{
+	TypeSff8636: {0x01, 0x02, 0x03},
+	TypeCmis:    {0x01, 0x02, 0x03, 0x10, 0x11},
+}
+
+// flatMemory converts paged memory back to the flat layout of its type.
+// Trailing upper pages and A2h that are not present are left out, so they are
+// not decoded as zeros later on.
+func flatMemory(m *common.Memory) []byte {
+	var b []byte
+	t, _ := GetMemoryType(m)
+	switch t {
+	case TypeSff8079:
+		b = m.FlatSff8079()
+		if !m.Has(common.LowerPage(common.AddressA2)) {
+			return b[:2*common.PageLen]
+		}
+		return b
+	case TypeCmis:
+		b = m.FlatCmis()
+	default:
+		t = TypeSff8636
+		b = m.FlatSff8636()
+	}
+	pages := flatPages[t]
+	n := len(pages)
+	for n > 0 && !m.Has(common.UpperPage(common.AddressA0, 0, pages[n-1])) {
+		n--
+	}
+	return b[:(2+n)*common.PageLen]
+}
+
 // I2CReader implements Reader interface for I2C devices
 type I2CReader struct {
 	path string
+	open func(addr uint8) (*I2C, error)
 }
 
 // NewI2CReader creates a new I2CReader for the given device path
 func NewI2CReader(path string) *I2CReader {
-	return &I2CReader{path: path}
+	return &I2CReader{path: path, open: func(addr uint8) (*I2C, error) {
+		return NewI2C(path, addr)
+	}}
 }
 
-// Read implements the Reader interface for I2C devices
+// Read implements the Reader interface for I2C devices. SFP modules are
+// returned as A0h (0x50) followed by A2h (0x51) if present, QSFP modules as the
+// lower page followed by upper page 00h. Missing memory is not padded.
 func (r *I2CReader) Read() ([]byte, error) {
-	// 0x50 and 0x51 SFP port
-	i, err := NewI2C(r.path, 0x50)
+	i, err := r.open(common.AddressA0)
+	if err != nil {
+		return nil, err
+	}
+	defer i.Close()
+
+	b, err := i.ReadAt(0, common.PageLen)
+	if err != nil {
+		return nil, fmt.Errorf("reading A0h lower page: %w", err)
+	}
+
+	if !isSff8079(b[0]) {
+		// Paged modules need an explicit page select (byte 127) as the
+		// module might have been left on another page
+		if err := i.SelectPage(0, 0); err != nil {
+			return nil, fmt.Errorf("selecting upper page 00h: %w", err)
+		}
+	}
+	upper, err := i.ReadAt(common.PageLen, common.PageLen)
+	if err != nil {
+		return nil, fmt.Errorf("reading A0h upper page: %w", err)
+	}
+	b = append(b, upper...)
+
+	// A2h is only present if DDM is implemented and no address change
+	// sequence is required (byte 92)
+	if isSff8079(b[0]) && b[92]&0x40 != 0 && b[92]&0x04 == 0 {
+		a2, err := r.open(common.AddressA2)
+		if err != nil {
+			return nil, err
+		}
+		defer a2.Close()
+
+		d, err := a2.ReadAt(0, 2*common.PageLen)
+		if err != nil {
+			return nil, fmt.Errorf("reading A2h: %w", err)
+		}
+		b = append(b, d...)
+	}
+
+	return b, nil
+}
+
+// ReadRegion implements the PagedReader interface for I2C devices
+func (r *I2CReader) ReadRegion(region common.Region) ([]byte, error) {
+	if err := region.Validate(); err != nil {
+		return nil, err
+	}
+	i, err := r.open(region.Address)
 	if err != nil {
 		return nil, err
 	}
 	defer i.Close()
-	i.Write([]byte{0x00})
-	b := make([]byte, 512)
-	i.Read(b)
+
+	b := make([]byte, 0, region.Length)
+	for _, p := range region.Split() {
+		if p.IsUpper() && (p.Bank != 0 || p.Page != 0) {
+			if err := i.SelectPage(p.Bank, p.Page); err != nil {
+				return nil, fmt.Errorf("selecting %s: %w", p, err)
+			}
+			// Leave the module on bank 0 page 00h for other users of the bus
+			if p.Bank != 0 {
+				defer i.Write([]byte{126, 0, 0})
+			} else {
+				defer i.SelectPage(0, 0)
+			}
+		}
+		d, err := i.ReadAt(byte(p.Offset), p.Length)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", p, err)
+		}
+		b = append(b, d...)
+	}
 	return b, nil
 }
 
+// WriteRegion implements the PagedWriter interface for I2C devices. Modules
+// limit the length of sequential writes, so b is written one byte at a time.
+func (r *I2CReader) WriteRegion(region common.Region, b []byte) error {
+	if err := region.Validate(); err != nil {
+		return err
+	}
+	if len(b) != region.Length {
+		return fmt.Errorf("writing %s: got %d bytes", region, len(b))
+	}
+	i, err := r.open(region.Address)
+	if err != nil {
+		return err
+	}
+	defer i.Close()
+
+	for _, p := range region.Split() {
+		if p.IsUpper() && (p.Bank != 0 || p.Page != 0) {
+			if err := i.SelectPage(p.Bank, p.Page); err != nil {
+				return fmt.Errorf("selecting %s: %w", p, err)
+			}
+			if p.Bank != 0 {
+				defer i.Write([]byte{126, 0, 0})
+			} else {
+				defer i.SelectPage(0, 0)
+			}
+		}
+		for n := 0; n < p.Length; n++ {
+			if err := i.WriteAt(byte(p.Offset+n), b[n:n+1]); err != nil {
+				return fmt.Errorf("writing %s: %w", p, err)
+			}
+		}
+		b = b[p.Length:]
+	}
+	return nil
+}
+
 // FileReader implements Reader interface for file-based reading
 type FileReader struct {
 	path string
@@ -106,55 +428,169 @@ func NewFileReader(path string) *FileReader {
 	return &FileReader{path: path}
 }
 
-// Read implements the Reader interface for file-based reading
+// Read implements the Reader interface for file-based reading. The file may
+// hold any of the formats accepted by ParseDump, it is returned in the flat
+// layout without padding.
 func (r *FileReader) Read() ([]byte, error) {
-	file, err := os.Open(r.path)
+	d, err := r.open()
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
+	return d.Read()
+}
 
-	b := make([]byte, 512)
-	n, err := file.Read(b)
+// ReadRegion implements the PagedReader interface for file-based reading.
+// The file is read on every call, so a dump that is rewritten is picked up.
+func (r *FileReader) ReadRegion(region common.Region) ([]byte, error) {
+	d, err := r.open()
 	if err != nil {
 		return nil, err
 	}
+	return d.ReadRegion(region)
+}
 
-	// If we read less than 512 bytes, pad with zeros
-	if n < 512 {
-		for i := n; i < 512; i++ {
-			b[i] = 0
-		}
+// open reads and parses the file
+func (r *FileReader) open() (*DumpReader, error) {
+	b, err := os.ReadFile(r.path)
+	if err != nil {
+		return nil, err
 	}
-
-	return b, nil
+	d, err := NewDumpReader(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", r.path, err)
+	}
+	return d, nil
 }
 
-// Read reads SFF EEPROM data using the provided Reader interface
-func Read(reader Reader) (*Module, error) {
-	eeprom, err := reader.Read()
-	if err != nil {
+// ReadMemory reads all memory regions the module advertises using the
+// provided PagedReader
+func ReadMemory(reader PagedReader) (*common.Memory, error) {
+	m := common.NewMemory()
+	read := func(region common.Region, optional bool) error {
+		b, err := reader.ReadRegion(region)
+		if optional && errors.Is(err, common.ErrRegionNotPresent) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return m.Write(region, b)
+	}
+
+	a0 := common.Region{Address: common.AddressA0, Offset: 0, Length: 2 * common.PageLen}
+	if err := read(a0, false); err != nil {
 		return nil, err
 	}
+	b, _ := m.Read(a0)
+
+	switch {
+	case isSff8079(b[0]):
+		// A2h is only present if DDM is implemented and no address change
+		// sequence is required (byte 92)
+		if b[92]&0x40 == 0 || b[92]&0x04 != 0 {
+			break
+		}
+		if err := read(common.LowerPage(common.AddressA2), true); err != nil {
+			return nil, err
+		}
+		if err := read(common.UpperPage(common.AddressA2, 0, 0), true); err != nil {
+			return nil, err
+		}
+	case cmis.IsCmis(b[0]):
+		// Flat memory modules only implement upper page 00h (byte 2 bit 7)
+		if b[2]&0x80 != 0 {
+			break
+		}
+		// Bank 0 of the advertising, threshold and lane pages. Their
+		// presence is not advertised consistently across CMIS revisions.
+		for _, page := range []uint8{0x01, 0x02, 0x10, 0x11} {
+			if err := read(common.UpperPage(common.AddressA0, 0, page), true); err != nil {
+				return nil, err
+			}
+		}
+	case isSff8636(b[0]) || isSff8636(b[128]):
+		// Flat memory modules only implement upper page 00h (byte 2 bit 2)
+		if b[2]&0x04 != 0 {
+			break
+		}
+		// Pages 01h and 02h are optional (byte 195), page 03h is always
+		// present on paged modules
+		if b[195]&0x40 != 0 {
+			if err := read(common.UpperPage(common.AddressA0, 0, 1), true); err != nil {
+				return nil, err
+			}
+		}
+		if b[195]&0x80 != 0 {
+			if err := read(common.UpperPage(common.AddressA0, 0, 2), true); err != nil {
+				return nil, err
+			}
+		}
+		if err := read(common.UpperPage(common.AddressA0, 0, 3), true); err != nil {
+			return nil, err
+		}
+	}
 
-	t, err := GetType(eeprom)
+	return m, nil
+}
+
+// Decode decodes paged module memory
+func Decode(mem *common.Memory) (*Module, error) {
+	t, err := GetMemoryType(mem)
 	if err != nil {
 		return nil, err
 	}
 
 	switch t {
 	case TypeSff8079:
-		m, err := sff8079.Decode(eeprom)
+		m, err := sff8079.DecodeMemory(mem)
 		if err != nil {
 			return nil, err
 		}
-		return &Module{Type: TypeSff8079, Sff8079: m}, nil
+		return &Module{Type: TypeSff8079, Sff8079: m, Memory: mem}, nil
 	case TypeSff8636:
-		m, err := sff8636.Decode(eeprom)
+		m, err := sff8636.DecodeMemory(mem)
+		if err != nil {
+			return nil, err
+		}
+		return &Module{Type: TypeSff8636, Sff8636: m, Memory: mem}, nil
+	case TypeCmis:
+		m, err := cmis.DecodeMemory(mem)
 		if err != nil {
 			return nil, err
 		}
-		return &Module{Type: TypeSff8636, Sff8636: m}, nil
+		return &Module{Type: TypeCmis, Cmis: m, Memory: mem}, nil
 	}
 	return nil, ErrUnknownType
 }
+
+// ReadPaged reads and decodes SFF module memory using the provided PagedReader
+func ReadPaged(reader PagedReader) (*Module, error) {
+	m, err := ReadMemory(reader)
+	if err != nil {
+		return nil, err
+	}
+	return Decode(m)
+}
+
+// Read reads SFF EEPROM data using the provided Reader interface. Readers
+// that also implement PagedReader are read page by page, the flat dumps of
+// other Readers are converted with ParseFlat.
+func Read(reader Reader) (*Module, error) {
+	if r, ok := reader.(PagedReader); ok {
+		return ReadPaged(r)
+	}
+
+	eeprom, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := GetType(eeprom); err != nil {
+		return nil, err
+	}
+	m, err := ParseFlat(eeprom)
+	if err != nil {
+		return nil, err
+	}
+	return Decode(m)
+}
@@ -0,0 +1,151 @@
+package sff8079
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+// CalibrationMode is the diagnostic calibration scheme declared in byte 92
+type CalibrationMode int
+
+const (
+	CalibrationNone     CalibrationMode = iota // No digital diagnostics implemented
+	CalibrationInternal                        // Values are calibrated by the module
+	CalibrationExternal                        // Values are calibrated with the A2h 56-91 constants
+)
+
+var calibrationModeNames = map[CalibrationMode]string{
+	CalibrationNone:     "None",
+	CalibrationInternal: "Internal",
+	CalibrationExternal: "External",
+}
+
+func (c CalibrationMode) String() string {
+	n, ok := calibrationModeNames[c]
+	if !ok {
+		return "Unknown"
+	}
+	return n
+}
+
+func (c CalibrationMode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c *CalibrationMode) UnmarshalJSON(in []byte) error {
+	var s string
+	if err := json.Unmarshal(in, &s); err != nil {
+		return err
+	}
+	for k, v := range calibrationModeNames {
+		if v == s {
+			*c = k
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown calibration mode %q", s)
+}
+
+// Calibration holds the external calibration constants (A2h bytes 56-91)
+type Calibration struct {
+	RxPwr       [5]common.Float32BE `json:"rxPwr"`       // 56-75 - Rx_PWR(4) to Rx_PWR(0)
+	TxISlope    common.UFixed8_8BE  `json:"txISlope"`    // 76-77 - Tx_I(Slope)
+	TxIOffset   common.Int16BE      `json:"txIOffset"`   // 78-79 - Tx_I(Offset)
+	TxPwrSlope  common.UFixed8_8BE  `json:"txPwrSlope"`  // 80-81 - Tx_PWR(Slope)
+	TxPwrOffset common.Int16BE      `json:"txPwrOffset"` // 82-83 - Tx_PWR(Offset)
+	TSlope      common.UFixed8_8BE  `json:"tSlope"`      // 84-85 - T(Slope)
+	TOffset     common.Int16BE      `json:"tOffset"`     // 86-87 - T(Offset)
+	VSlope      common.UFixed8_8BE  `json:"vSlope"`      // 88-89 - V(Slope)
+	VOffset     common.Int16BE      `json:"vOffset"`     // 90-91 - V(Offset)
+}
+
+// calibrationMode returns the calibration mode declared by the diagnostic
+// monitoring type (byte 92)
+func calibrationMode(d DiagnosticMonitoringType) CalibrationMode {
+	switch {
+	case !d.IsDdmImplemented():
+		return CalibrationNone
+	case d.IsExternallyCalibrated() && !d.IsInternallyCalibrated():
+		return CalibrationExternal
+	}
+	return CalibrationInternal
+}
+
+// clampUint16 rounds v and limits it to the range of an unsigned 16-bit value.
+// NaN, e.g. from Rx_PWR constants of FFFFFFFFh, gives 0.
+func clampUint16(v float64) uint16 {
+	if math.IsNaN(v) {
+		return 0
+	}
+	return uint16(math.Max(0, math.Min(math.MaxUint16, math.Round(v))))
+}
+
+// linear applies slope and offset to an unsigned A/D reading
+func linear(raw [2]byte, slope common.UFixed8_8BE, offset common.Int16BE) [2]byte {
+	v := clampUint16(slope.Float64()*float64(uint16(raw[0])<<8|uint16(raw[1])) + float64(offset.Int16()))
+	return [2]byte{byte(v >> 8), byte(v)}
+}
+
+func (c *Calibration) temperature(t common.TemperatureQ8_8BE) common.TemperatureQ8_8BE {
+	v := math.Round(c.TSlope.Float64()*float64(t.Raw()) + float64(c.TOffset.Int16()))
+	r := uint16(int16(math.Max(math.MinInt16, math.Min(math.MaxInt16, v))))
+	return common.TemperatureQ8_8BE{byte(r >> 8), byte(r)}
+}
+
+func (c *Calibration) vcc(v common.VoltageVoltBE) common.VoltageVoltBE {
+	return linear(v, c.VSlope, c.VOffset)
+}
+
+func (c *Calibration) txBias(b common.CurrentMilliAmpBE) common.CurrentMilliAmpBE {
+	return linear(b, c.TxISlope, c.TxIOffset)
+}
+
+func (c *Calibration) txPower(p common.PowerMilliWattBE) common.PowerMilliWattBE {
+	return linear(p, c.TxPwrSlope, c.TxPwrOffset)
+}
+
+// rxPower evaluates the Rx_PWR(4..0) polynomial for the A/D reading
+func (c *Calibration) rxPower(p common.PowerMilliWattBE) common.PowerMilliWattBE {
+	ad := float64(p.Raw())
+	v := 0.0
+	for _, k := range c.RxPwr {
+		v = v*ad + float64(k.Float32())
+	}
+	r := clampUint16(v)
+	return common.PowerMilliWattBE{byte(r >> 8), byte(r)}
+}
+
+// calibrate sets CalibrationMode and, for externally calibrated modules,
+// converts the A/D readings and thresholds to calibrated values in place
+func (s *Sff8079) calibrate() {
+	s.CalibrationMode = calibrationMode(s.DiagMonitType)
+	if s.CalibrationMode != CalibrationExternal {
+		return
+	}
+	c := &s.Calibration
+
+	s.Temperature = c.temperature(s.Temperature)
+	s.Vcc = c.vcc(s.Vcc)
+	s.TxBias = c.txBias(s.TxBias)
+	s.TxPower = c.txPower(s.TxPower)
+	s.RxPower = c.rxPower(s.RxPower)
+
+	t := &s.TempThresholds
+	t.HighAlarm, t.LowAlarm = c.temperature(t.HighAlarm), c.temperature(t.LowAlarm)
+	t.HighWarning, t.LowWarning = c.temperature(t.HighWarning), c.temperature(t.LowWarning)
+	v := &s.VccThresholds
+	v.HighAlarm, v.LowAlarm = c.vcc(v.HighAlarm), c.vcc(v.LowAlarm)
+	v.HighWarning, v.LowWarning = c.vcc(v.HighWarning), c.vcc(v.LowWarning)
+	b := &s.TxBiasThresholds
+	b.HighAlarm, b.LowAlarm = c.txBias(b.HighAlarm), c.txBias(b.LowAlarm)
+	b.HighWarning, b.LowWarning = c.txBias(b.HighWarning), c.txBias(b.LowWarning)
+	tx := &s.TxPowerThresholds
+	tx.HighAlarm, tx.LowAlarm = c.txPower(tx.HighAlarm), c.txPower(tx.LowAlarm)
+	tx.HighWarning, tx.LowWarning = c.txPower(tx.HighWarning), c.txPower(tx.LowWarning)
+	rx := &s.RxPowerThresholds
+	rx.HighAlarm, rx.LowAlarm = c.rxPower(rx.HighAlarm), c.rxPower(rx.LowAlarm)
+	rx.HighWarning, rx.LowWarning = c.rxPower(rx.HighWarning), c.rxPower(rx.LowWarning)
+}
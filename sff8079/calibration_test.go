@@ -0,0 +1,117 @@
+package sff8079
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"unsafe"
+)
+
+func testEeprom(diagMonitType byte) []byte {
+	b := make([]byte, eepromLen)
+	b[0], b[1] = 0x03, 0x04
+	b[92] = diagMonitType
+	return b
+}
+
+// externalEeprom returns an externally calibrated test module
+func externalEeprom() []byte {
+	b := testEeprom(0x50)
+	a2 := b[256:]
+	copy(a2[0:2], []byte{0x50, 0x00})               // Temperature high alarm, 80 C raw
+	copy(a2[68:72], []byte{0x3f, 0xc0, 0, 0})       // Rx_PWR(1) = 1.5
+	copy(a2[72:76], []byte{0x42, 0xc8, 0, 0})       // Rx_PWR(0) = 100
+	copy(a2[76:80], []byte{0x02, 0x00, 0x00, 0x10}) // Tx_I slope 2.0, offset 16
+	copy(a2[80:84], []byte{0x00, 0x80, 0x00, 0x00}) // Tx_PWR slope 0.5, offset 0
+	copy(a2[84:88], []byte{0x01, 0x00, 0xff, 0x00}) // T slope 1.0, offset -1 C
+	copy(a2[88:92], []byte{0x01, 0x00, 0x00, 0x00}) // V slope 1.0, offset 0
+	copy(a2[96:106], []byte{0x19, 0x00, 0x80, 0xe8, 0x01, 0x00, 0x20, 0x00, 0x10, 0x00})
+	return b
+}
+
+func TestDecodeExternalCalibration(t *testing.T) {
+	b := externalEeprom()
+	orig := append([]byte{}, b...)
+
+	s, err := Decode(b)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if s.CalibrationMode != CalibrationExternal {
+		t.Errorf("CalibrationMode = %s, want %s", s.CalibrationMode, CalibrationExternal)
+	}
+	checks := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"Temperature", s.Temperature.Celsius(), 24},
+		{"Vcc", s.Vcc.Volts(), 3.3000},
+		{"TxBias", s.TxBias.MilliAmp(), 1.056},
+		{"TxPower", s.TxPower.MilliWatt(), 0.4096},
+		{"RxPower", s.RxPower.MilliWatt(), 0.6244},
+		{"TempThresholds.HighAlarm", s.TempThresholds.HighAlarm.Celsius(), 79},
+	}
+	for _, c := range checks {
+		if math.Abs(c.got-c.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	for i := range b {
+		if b[i] != orig[i] {
+			t.Fatalf("Decode modified the eeprom buffer at byte %d", i)
+		}
+	}
+}
+
+func TestUnsetCalibrationConstants(t *testing.T) {
+	for _, diag := range []byte{0x50, 0x68} {
+		b := externalEeprom()
+		b[92] = diag
+		for i := 256 + 56; i < 256+76; i++ {
+			b[i] = 0xff // Rx_PWR(4) to Rx_PWR(0) as NaN
+		}
+
+		s, err := Decode(b)
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if diag == 0x50 && s.RxPower.Raw() != 0 {
+			t.Errorf("RxPower = %s with NaN constants, want 0", s.RxPower)
+		}
+		if _, err := json.Marshal(s); err != nil {
+			t.Errorf("Marshal failed for diagnostic monitoring type 0x%02x: %v", diag, err)
+		}
+	}
+}
+
+func TestDecodeInternalCalibration(t *testing.T) {
+	b := testEeprom(0x68)
+	copy(b[256+84:256+88], []byte{0x01, 0x00, 0xff, 0x00})
+	copy(b[256+96:256+98], []byte{0x19, 0x00})
+
+	s, err := Decode(b)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if s.CalibrationMode != CalibrationInternal {
+		t.Errorf("CalibrationMode = %s, want %s", s.CalibrationMode, CalibrationInternal)
+	}
+	if s.Temperature.Celsius() != 25 {
+		t.Errorf("Temperature = %v, want 25", s.Temperature.Celsius())
+	}
+
+	if s, _ := Decode(testEeprom(0x00)); s.CalibrationMode != CalibrationNone {
+		t.Errorf("CalibrationMode = %s, want %s", s.CalibrationMode, CalibrationNone)
+	}
+}
+
+func TestSff8079Layout(t *testing.T) {
+	var s Sff8079
+	if o := unsafe.Offsetof(s.Calibration); o != 256+56 {
+		t.Errorf("Calibration at offset %d, want %d", o, 256+56)
+	}
+	if o := unsafe.Offsetof(s.CalibrationMode); o != mappedLen {
+		t.Errorf("CalibrationMode at offset %d, want %d", o, mappedLen)
+	}
+}
@@ -0,0 +1,58 @@
+package sff8079
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// Sff8472Compliance represents the SFF-8472 Compliance field (Byte 94)
+type Sff8472Compliance byte
+
+const (
+	Sff8472NotIncluded Sff8472Compliance = 0x00
+	Sff8472Rev93       Sff8472Compliance = 0x01
+	Sff8472Rev95       Sff8472Compliance = 0x02
+	Sff8472Rev102      Sff8472Compliance = 0x03
+	Sff8472Rev104      Sff8472Compliance = 0x04
+	Sff8472Rev110      Sff8472Compliance = 0x05
+	Sff8472Rev113      Sff8472Compliance = 0x06
+	Sff8472Rev114      Sff8472Compliance = 0x07
+	Sff8472Rev123      Sff8472Compliance = 0x08
+)
+
+var sff8472ComplianceNames = map[Sff8472Compliance]string{
+	Sff8472NotIncluded: "Digital diagnostic functionality not included or undefined",
+	Sff8472Rev93:       "SFF-8472 Rev 9.3",
+	Sff8472Rev95:       "SFF-8472 Rev 9.5",
+	Sff8472Rev102:      "SFF-8472 Rev 10.2",
+	Sff8472Rev104:      "SFF-8472 Rev 10.4",
+	Sff8472Rev110:      "SFF-8472 Rev 11.0",
+	Sff8472Rev113:      "SFF-8472 Rev 11.3",
+	Sff8472Rev114:      "SFF-8472 Rev 11.4",
+	Sff8472Rev123:      "SFF-8472 Rev 12.3",
+}
+
+func (c Sff8472Compliance) String() string {
+	if name, ok := sff8472ComplianceNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unallocated (0x%02x)", byte(c))
+}
+
+func (c Sff8472Compliance) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": c.String(),
+		"hex":   hex.EncodeToString([]byte{byte(c)}),
+	}
+	return json.Marshal(m)
+}
+
+func (c *Sff8472Compliance) UnmarshalJSON(in []byte) error {
+	var b [1]byte
+	if err := unmarshalHex(in, b[:], "Sff8472Compliance"); err != nil {
+		return err
+	}
+	*c = Sff8472Compliance(b[0])
+	return nil
+}
@@ -0,0 +1,114 @@
+package sff8079
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+func TestCableCompliance(t *testing.T) {
+	b := testEeprom(0x68)
+	b[8] = 0x04  // Passive cable
+	b[60] = 0x01 // SFF-8431 Appendix E
+
+	s, err := Decode(b)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if !s.IsPassiveCable() || s.IsActiveCable() {
+		t.Fatalf("Expected passive cable for byte 8 0x%02x", b[8])
+	}
+	if want := []string{"SFF-8431 Appendix E"}; !reflect.DeepEqual(s.CableCompliance, want) {
+		t.Errorf("CableCompliance = %v, want %v", s.CableCompliance, want)
+	}
+
+	b[8], b[60], b[61] = 0x00, 0x03, 0x52
+	if s, _ = Decode(b); s.CableCompliance != nil || s.LaserWavelength.Nanometers() != 850 {
+		t.Errorf("Expected 850 nm optical module, got %s compliance %v", s.LaserWavelength, s.CableCompliance)
+	}
+}
+
+func TestTypedBytesJSON(t *testing.T) {
+	type typed struct {
+		W LaserWavelength          `json:"w"`
+		D DiagnosticMonitoringType `json:"d"`
+		E EnhancedOptions          `json:"e"`
+		C Sff8472Compliance        `json:"c"`
+	}
+	in := typed{LaserWavelength{0x05, 0x1e}, 0x68, 0xf0, Sff8472Rev110}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var out typed
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if out != in {
+		t.Errorf("Round trip gave %+v, want %+v", out, in)
+	}
+
+	if !in.D.IsDdmImplemented() || !in.D.IsInternallyCalibrated() || in.D.IsExternallyCalibrated() {
+		t.Errorf("Unexpected diagnostic monitoring type bits for 0x%02x", byte(in.D))
+	}
+	if !in.E.IsSoftTxDisableImplemented() || in.E.IsSoftRateSelectImplemented() {
+		t.Errorf("Unexpected enhanced options bits for 0x%02x", byte(in.E))
+	}
+	if got := Sff8472Compliance(0x42).String(); got != "Unallocated (0x42)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestTypedBytesUnmarshalJSONInvalid(t *testing.T) {
+	for _, in := range []string{`{}`, `{"hex": 1}`, `{"hex": ""}`, `{"hex": "zz"}`} {
+		for _, v := range []json.Unmarshaler{new(LaserWavelength), new(DiagnosticMonitoringType), new(EnhancedOptions), new(Sff8472Compliance)} {
+			if err := v.UnmarshalJSON([]byte(in)); err == nil {
+				t.Errorf("%T: expected error for %s", v, in)
+			}
+		}
+	}
+	var w LaserWavelength
+	if err := json.Unmarshal([]byte(`{"hex": "05"}`), &w); err == nil {
+		t.Errorf("LaserWavelength: expected error for one byte")
+	}
+}
+
+func TestExtendedCompliance(t *testing.T) {
+	b := testEeprom(0x68)
+	b[3], b[36] = 0x00, 0x02 // 25GBASE-SR SFP28
+
+	s, err := Decode(b)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if want := []string{"Extended: 100GBASE-SR4 or 25GBASE-SR"}; !reflect.DeepEqual(s.TransceiverList(), want) {
+		t.Errorf("TransceiverList() = %v, want %v", s.TransceiverList(), want)
+	}
+
+	// ethtool has its own names
+	if want := []string{"Extended: 100G Base-SR4 or 25GBase-SR"}; !reflect.DeepEqual(s.ethtoolTransceiverList(), want) {
+		t.Errorf("ethtoolTransceiverList() = %v, want %v", s.ethtoolTransceiverList(), want)
+	}
+
+	j, err := json.Marshal(s.TranscComp)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var got common.ExtendedCompliance
+	if err := json.Unmarshal(j, &got); err != nil || got != s.TranscComp {
+		t.Errorf("JSON round trip = %v, %v, want %v", got, err, s.TranscComp)
+	}
+
+	b[36] = 0x00
+	if s, _ = Decode(b); len(s.TransceiverList()) != len(s.Transceiver.List()) {
+		t.Errorf("Unspecified extended compliance listed: %v", s.TransceiverList())
+	}
+
+	// ethtool prints nothing for codes it does not know, e.g. 100G CLR4
+	b[36] = 0x17
+	if s, _ = Decode(b); len(s.ethtoolTransceiverList()) != 0 {
+		t.Errorf("ethtoolTransceiverList() = %v for code 0x17, want none", s.ethtoolTransceiverList())
+	}
+}
@@ -0,0 +1,113 @@
+package sff8079
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+// DiagnosticMonitoringType represents the Diagnostic Monitoring Type field from
+// SFF-8472 specification Table 8-5 (Byte 92)
+type DiagnosticMonitoringType byte
+
+// Decode converts the raw byte to structured bit fields
+func (d DiagnosticMonitoringType) Decode() DiagnosticMonitoringTypeBits {
+	return DiagnosticMonitoringTypeBits{
+		Legacy:                (byte(d) & 0x80) != 0, // Bit 7: Reserved for legacy diagnostic implementations
+		DdmImplemented:        (byte(d) & 0x40) != 0, // Bit 6: Digital diagnostic monitoring implemented
+		InternallyCalibrated:  (byte(d) & 0x20) != 0, // Bit 5: Internally calibrated
+		ExternallyCalibrated:  (byte(d) & 0x10) != 0, // Bit 4: Externally calibrated
+		ReceivedPowerAverage:  (byte(d) & 0x08) != 0, // Bit 3: Received power measurement type (0=OMA, 1=Average Power)
+		AddressChangeRequired: (byte(d) & 0x04) != 0, // Bit 2: Address change required
+		ReservedBits:          (byte(d) & 0x03) != 0, // Bits 1-0: Unallocated
+	}
+}
+
+// DiagnosticMonitoringTypeBits represents the individual bit fields of the Diagnostic Monitoring Type byte
+type DiagnosticMonitoringTypeBits struct {
+	Legacy                bool // Bit 7: Reserved for legacy diagnostic implementations
+	DdmImplemented        bool // Bit 6: Digital diagnostic monitoring implemented
+	InternallyCalibrated  bool // Bit 5: Internally calibrated
+	ExternallyCalibrated  bool // Bit 4: Externally calibrated
+	ReceivedPowerAverage  bool // Bit 3: Received power measurement type (0=OMA, 1=Average Power)
+	AddressChangeRequired bool // Bit 2: Address change required
+	ReservedBits          bool // Bits 1-0: Unallocated
+}
+
+// List returns a slice of all implemented diagnostic monitoring features as strings
+func (d DiagnosticMonitoringType) List() []string {
+	bits := d.Decode()
+	var features []string
+
+	if !bits.DdmImplemented {
+		return features
+	}
+	features = append(features, "Digital diagnostic monitoring implemented")
+	if bits.InternallyCalibrated {
+		features = append(features, "Internally calibrated")
+	}
+	if bits.ExternallyCalibrated {
+		features = append(features, "Externally calibrated")
+	}
+	if bits.ReceivedPowerAverage {
+		features = append(features, "Received power measurement type: Average Power")
+	} else {
+		features = append(features, "Received power measurement type: OMA")
+	}
+	if bits.AddressChangeRequired {
+		features = append(features, "Address change required")
+	}
+
+	return features
+}
+
+// String returns a human-readable representation of the Diagnostic Monitoring Type field
+func (d DiagnosticMonitoringType) String() string {
+	l := d.List()
+	if len(l) == 0 {
+		return "No diagnostic monitoring implemented"
+	}
+	return strings.Join(l, ", ")
+}
+
+// IsDdmImplemented returns true if digital diagnostic monitoring is implemented
+func (d DiagnosticMonitoringType) IsDdmImplemented() bool {
+	return (byte(d) & 0x40) != 0
+}
+
+// IsInternallyCalibrated returns true if the diagnostics are internally calibrated
+func (d DiagnosticMonitoringType) IsInternallyCalibrated() bool {
+	return (byte(d) & 0x20) != 0
+}
+
+// IsExternallyCalibrated returns true if the diagnostics are externally calibrated
+func (d DiagnosticMonitoringType) IsExternallyCalibrated() bool {
+	return (byte(d) & 0x10) != 0
+}
+
+// IsReceivedPowerAverage returns true if received power is measured as average power
+func (d DiagnosticMonitoringType) IsReceivedPowerAverage() bool {
+	return (byte(d) & 0x08) != 0
+}
+
+// IsAddressChangeRequired returns true if an address change sequence is required to access A2h
+func (d DiagnosticMonitoringType) IsAddressChangeRequired() bool {
+	return (byte(d) & 0x04) != 0
+}
+
+func (d DiagnosticMonitoringType) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": d.List(),
+		"hex":   hex.EncodeToString([]byte{byte(d)}),
+	}
+	return json.Marshal(m)
+}
+
+func (d *DiagnosticMonitoringType) UnmarshalJSON(in []byte) error {
+	var b [1]byte
+	if err := unmarshalHex(in, b[:], "DiagnosticMonitoringType"); err != nil {
+		return err
+	}
+	*d = DiagnosticMonitoringType(b[0])
+	return nil
+}
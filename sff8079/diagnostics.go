@@ -0,0 +1,42 @@
+package sff8079
+
+import "github.com/bluecmd/go-sff/common"
+
+// Diagnostics returns the A2h diagnostic monitors as a single lane, with the
+// alarm states derived from the A2h thresholds. Externally calibrated values
+// are already calibrated, see CalibrationMode. Returns nil for modules
+// without digital diagnostics or if A2h was not read.
+func (s *Sff8079) Diagnostics() *common.Diagnostics {
+	if s.CalibrationMode == CalibrationNone || !s.hasA2h() {
+		return nil
+	}
+	return &common.Diagnostics{
+		Temperature:      s.Temperature,
+		Vcc:              s.Vcc,
+		TemperatureState: s.TempThresholds.State(s.Temperature),
+		VccState:         s.VccThresholds.State(s.Vcc),
+		Lanes: []common.LaneDiagnostics{{
+			Lane:         1,
+			TxBias:       s.TxBias,
+			TxPower:      s.TxPower,
+			RxPower:      s.RxPower,
+			TxBiasState:  s.TxBiasThresholds.State(s.TxBias),
+			TxPowerState: s.TxPowerThresholds.State(s.TxPower),
+			RxPowerState: s.RxPowerThresholds.State(s.RxPower),
+		}},
+	}
+}
+
+// hasA2h returns false if s was decoded from memory without the A2h lower
+// page. Values not decoded from memory are assumed to be complete.
+func (s *Sff8079) hasA2h() bool {
+	if s.regions == nil {
+		return true
+	}
+	for _, r := range s.regions {
+		if r == common.LowerPage(common.AddressA2) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package sff8079
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+// flatLen returns the length of the flat dump covering the regions present
+// in m: A0h, A0h and the A2h lower page, or A0h and both A2h pages
+func flatLen(m *common.Memory) int {
+	switch {
+	case !m.Has(common.LowerPage(common.AddressA2)):
+		return 2 * common.PageLen
+	case !m.Has(common.UpperPage(common.AddressA2, 0, 0)):
+		return mappedLen
+	}
+	return eepromLen
+}
+
+// calibratedWords lists the A2h offsets of the values converted by
+// calibrate: the thresholds (0-39) and the monitors (96-105)
+var calibratedWords = []int{
+	0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 38,
+	96, 98, 100, 102, 104,
+}
+
+// Encode returns the flat A0h and A2h dump of s. Bytes not mapped by Sff8079,
+// such as the A2h upper page, are taken from the dump s was decoded from, so
+// decoding and encoding reproduces the dump exactly. Values that were not
+// decoded from a dump encode as 512 bytes with the unmapped bytes zero.
+//
+// With updateChecksums CC_BASE and CC_EXT are recomputed in the result, s is
+// not modified. Calibrated values of externally calibrated modules are
+// encoded as their original A/D readings and cannot be changed.
+func (s *Sff8079) Encode(updateChecksums bool) ([]byte, error) {
+	c := *s
+	if updateChecksums {
+		c.UpdateChecksums()
+	}
+
+	n := len(s.image)
+	if n == 0 {
+		n = eepromLen
+	}
+	b := make([]byte, n)
+	copy(b, s.image)
+	copy(b, c.bytes())
+
+	if s.CalibrationMode == CalibrationExternal && n >= mappedLen {
+		if err := s.uncalibrate(b); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// uncalibrate restores the A/D readings of the calibrated values in b
+func (s *Sff8079) uncalibrate(b []byte) error {
+	if s.image == nil {
+		return fmt.Errorf("externally calibrated values can only be encoded if decoded from a dump")
+	}
+	orig, err := Decode(s.image)
+	if err != nil {
+		return err
+	}
+	a2 := b[2*common.PageLen:]
+	for _, o := range calibratedWords {
+		if !bytes.Equal(a2[o:o+2], orig.bytes()[2*common.PageLen+o:][:2]) {
+			return fmt.Errorf("A2h bytes %d-%d: changed externally calibrated values cannot be encoded", o, o+1)
+		}
+		copy(a2[o:o+2], s.image[2*common.PageLen+o:])
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, see Encode
+func (s *Sff8079) MarshalBinary() ([]byte, error) {
+	return s.Encode(false)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, see Decode
+func (s *Sff8079) UnmarshalBinary(b []byte) error {
+	d, err := Decode(b)
+	if err != nil {
+		return err
+	}
+	*s = *d
+	return nil
+}
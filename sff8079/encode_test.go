@@ -0,0 +1,49 @@
+package sff8079
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	b := externalEeprom()
+	b[384] = 0xaa // A2h upper page, not mapped
+	s, err := Decode(b)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	// Calibrated values are encoded as the original A/D readings
+	got, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	if !bytes.Equal(got, b) {
+		t.Error("Encoding differs from the decoded dump")
+	}
+
+	// Mapped fields are encoded, s is not modified by updating checksums
+	s.Vendor[0] = 'X'
+	got, err = s.Encode(true)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if got[20] != 'X' || got[384] != 0xaa {
+		t.Errorf("Got vendor byte 0x%02x, A2h byte 128 0x%02x", got[20], got[384])
+	}
+	if ccBase.Verify(got) != nil || s.Validate() == nil {
+		t.Error("Encode(true) should only update the check codes of the result")
+	}
+
+	s.Temperature[0]++
+	if _, err := s.Encode(false); err == nil {
+		t.Error("Expected error encoding a changed calibrated value")
+	}
+
+	// Values not decoded from a dump
+	n := &Sff8079{}
+	n.Identifier = 0x03
+	if got, _ := n.Encode(false); len(got) != eepromLen || got[0] != 0x03 {
+		t.Errorf("Encode() of new value = %d bytes, want %d", len(got), eepromLen)
+	}
+}
@@ -1,64 +1,19 @@
 package sff8079
 
-import (
-	"encoding/hex"
-	"encoding/json"
-)
+import "github.com/bluecmd/go-sff/common"
 
+// Encoding values, SFF-8024 Table 4-2
 const (
-	EncodingUnspecified = 0x00
-	Encoding8b10b       = 0x01
-	Encoding4b5b        = 0x02
-	EncodingNrz         = 0x03
-	Encoding4h          = 0x04
-	Encoding5h          = 0x05
-	Encoding6h          = 0x06
-	Encoding256b        = 0x07
-	EncodingPam4        = 0x08
+	EncodingUnspecified = common.EncodingUnspecified
+	Encoding8b10b       = common.Encoding8b10b
+	Encoding4b5b        = common.Encoding4b5b
+	EncodingNrz         = common.EncodingNrz
+	Encoding4h          = common.Encoding4h
+	Encoding5h          = common.Encoding5h
+	Encoding6h          = common.Encoding6h
+	Encoding256b        = common.Encoding256b
+	EncodingPam4        = common.EncodingPam4
 )
 
-var encodingNames = map[byte]string{
-	EncodingUnspecified: "Unspecified",
-	Encoding8b10b:       "8B/10B",
-	Encoding4b5b:        "4B/5B",
-	EncodingNrz:         "NRZ",
-	Encoding4h:          "Manchester",
-	Encoding5h:          "SONET Scrambled",
-	Encoding6h:          "64B/66B",
-	Encoding256b:        "256B/257B (transcoded FEC-enabled data)",
-	EncodingPam4:        "PAM4",
-}
-
-type Encoding byte
-
-func (e Encoding) String() string {
-	n, ok := encodingNames[byte(e)]
-	if !ok {
-		return "Reserved or unknown"
-	}
-	return n
-}
-
-func (e Encoding) MarshalJSON() ([]byte, error) {
-	m := map[string]interface{}{
-		"value": e.String(),
-		"hex":   hex.EncodeToString([]byte{byte(e)}),
-	}
-	return json.Marshal(m)
-}
-
-func (e *Encoding) UnmarshalJSON(in []byte) error {
-	m := map[string]interface{}{}
-	err := json.Unmarshal(in, &m)
-	if err != nil {
-		return err
-	}
-
-	b, err := hex.DecodeString(m["hex"].(string))
-	if err != nil {
-		return err
-	}
-
-	*e = Encoding(b[0])
-	return nil
-}
+// Encoding is the serial encoding (byte 11)
+type Encoding = common.Encoding
@@ -0,0 +1,118 @@
+package sff8079
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+// EnhancedOptions represents the Enhanced Options field from SFF-8472
+// specification Table 8-6 (Byte 93)
+type EnhancedOptions byte
+
+// Decode converts the raw byte to structured bit fields
+func (e EnhancedOptions) Decode() EnhancedOptionsBits {
+	return EnhancedOptionsBits{
+		AlarmWarningFlags:     (byte(e) & 0x80) != 0, // Bit 7: Alarm/warning flags implemented
+		SoftTxDisable:         (byte(e) & 0x40) != 0, // Bit 6: Soft TX_DISABLE control and monitoring implemented
+		SoftTxFault:           (byte(e) & 0x20) != 0, // Bit 5: Soft TX_FAULT monitoring implemented
+		SoftRxLos:             (byte(e) & 0x10) != 0, // Bit 4: Soft RX_LOS monitoring implemented
+		SoftRateSelect:        (byte(e) & 0x08) != 0, // Bit 3: Soft RATE_SELECT control and monitoring implemented
+		ApplicationSelect:     (byte(e) & 0x04) != 0, // Bit 2: Application Select control implemented per SFF-8079
+		SoftRateSelectSff8431: (byte(e) & 0x02) != 0, // Bit 1: Soft Rate Select control implemented per SFF-8431
+		Reserved:              (byte(e) & 0x01) != 0, // Bit 0: Unallocated
+	}
+}
+
+// EnhancedOptionsBits represents the individual bit fields of the Enhanced Options byte
+type EnhancedOptionsBits struct {
+	AlarmWarningFlags     bool // Bit 7: Alarm/warning flags implemented
+	SoftTxDisable         bool // Bit 6: Soft TX_DISABLE control and monitoring implemented
+	SoftTxFault           bool // Bit 5: Soft TX_FAULT monitoring implemented
+	SoftRxLos             bool // Bit 4: Soft RX_LOS monitoring implemented
+	SoftRateSelect        bool // Bit 3: Soft RATE_SELECT control and monitoring implemented
+	ApplicationSelect     bool // Bit 2: Application Select control implemented per SFF-8079
+	SoftRateSelectSff8431 bool // Bit 1: Soft Rate Select control implemented per SFF-8431
+	Reserved              bool // Bit 0: Unallocated
+}
+
+// List returns a slice of all implemented enhanced options as strings
+func (e EnhancedOptions) List() []string {
+	bits := e.Decode()
+	var options []string
+
+	if bits.AlarmWarningFlags {
+		options = append(options, "Alarm/warning flags implemented")
+	}
+	if bits.SoftTxDisable {
+		options = append(options, "Soft TX_DISABLE implemented")
+	}
+	if bits.SoftTxFault {
+		options = append(options, "Soft TX_FAULT implemented")
+	}
+	if bits.SoftRxLos {
+		options = append(options, "Soft RX_LOS implemented")
+	}
+	if bits.SoftRateSelect {
+		options = append(options, "Soft RATE_SELECT implemented")
+	}
+	if bits.ApplicationSelect {
+		options = append(options, "Application Select per SFF-8079 implemented")
+	}
+	if bits.SoftRateSelectSff8431 {
+		options = append(options, "Soft Rate Select per SFF-8431 implemented")
+	}
+
+	return options
+}
+
+// String returns a human-readable representation of the Enhanced Options field
+func (e EnhancedOptions) String() string {
+	l := e.List()
+	if len(l) == 0 {
+		return "No enhanced options implemented"
+	}
+	return strings.Join(l, ", ")
+}
+
+// IsAlarmWarningFlagsImplemented returns true if the A2h alarm and warning flags are implemented
+func (e EnhancedOptions) IsAlarmWarningFlagsImplemented() bool {
+	return (byte(e) & 0x80) != 0
+}
+
+// IsSoftTxDisableImplemented returns true if soft TX_DISABLE control is implemented
+func (e EnhancedOptions) IsSoftTxDisableImplemented() bool {
+	return (byte(e) & 0x40) != 0
+}
+
+// IsSoftTxFaultImplemented returns true if soft TX_FAULT monitoring is implemented
+func (e EnhancedOptions) IsSoftTxFaultImplemented() bool {
+	return (byte(e) & 0x20) != 0
+}
+
+// IsSoftRxLosImplemented returns true if soft RX_LOS monitoring is implemented
+func (e EnhancedOptions) IsSoftRxLosImplemented() bool {
+	return (byte(e) & 0x10) != 0
+}
+
+// IsSoftRateSelectImplemented returns true if soft RATE_SELECT control is implemented
+func (e EnhancedOptions) IsSoftRateSelectImplemented() bool {
+	return (byte(e) & 0x08) != 0
+}
+
+func (e EnhancedOptions) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": e.List(),
+		"hex":   hex.EncodeToString([]byte{byte(e)}),
+	}
+	return json.Marshal(m)
+}
+
+func (e *EnhancedOptions) UnmarshalJSON(in []byte) error {
+	var b [1]byte
+	if err := unmarshalHex(in, b[:], "EnhancedOptions"); err != nil {
+		return err
+	}
+	*e = EnhancedOptions(b[0])
+	return nil
+}
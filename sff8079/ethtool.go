@@ -0,0 +1,287 @@
+package sff8079
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+var rateIdentifierNames = map[byte]string{
+	0x00: "unspecified",
+	0x01: "4/2/1G Rate_Select & AS0/AS1",
+	0x02: "8/4/2G Rx Rate_Select only",
+	0x03: "8/4/2G Independent Rx & Tx Rate_Select",
+	0x04: "8/4/2G Tx Rate_Select only",
+}
+
+// ethtoolExtComplianceNames are ethtool's names of the SFF-8024 extended
+// compliance codes (byte 36), from sfpid.c. ethtool prints nothing for codes
+// missing here. The codes 50h-55h are from an SFF-8024 revision that has
+// since reassigned them.
+var ethtoolExtComplianceNames = map[common.ExtendedCompliance]string{
+	common.ExtCompliance100gAoc5e5:    "100G AOC or 25GAUI C2M AOC with worst BER of 5x10^(-5)",
+	common.ExtCompliance100gBaseSr4:   "100G Base-SR4 or 25GBase-SR",
+	common.ExtCompliance100gBaseLr4:   "100G Base-LR4 or 25GBase-LR",
+	common.ExtCompliance100gBaseEr4:   "100G Base-ER4 or 25GBase-ER",
+	common.ExtCompliance100gAcc5e5:    "100G ACC or 25GAUI C2M ACC with worst BER of 5x10^(-5)",
+	common.ExtCompliance100gBaseCr4:   "100G Base-CR4 or 25GBase-CR CA-L",
+	common.ExtCompliance25gBaseCrS:    "25GBase-CR CA-S",
+	common.ExtCompliance25gBaseCrN:    "25GBase-CR CA-N",
+	common.ExtCompliance10gBaseTSfi:   "10Gbase-T with SFI electrical interface",
+	common.ExtCompliance100gAoc1e12:   "100G AOC or 25GAUI C2M AOC with worst BER of 10^(-12)",
+	common.ExtCompliance100gAcc1e12:   "100G ACC or 25GAUI C2M ACC with worst BER of 10^(-12)",
+	common.ExtCompliance100geDwdm2:    "100GE-DWDM2 (DWDM transceiver using 2 wavelengths on a 1550 nm DWDM grid with a reach up to 80 km)",
+	common.ExtCompliance100gWdm1550:   "100G 1550nm WDM (4 wavelengths)",
+	common.ExtCompliance10gBaseTSr:    "10Gbase-T Short Reach (30 meters)",
+	common.ExtCompliance5gBaseT:       "5GBASE-T",
+	common.ExtCompliance2500BaseT:     "2.5GBASE-T",
+	common.ExtCompliance40gSwdm4:      "40G SWDM4",
+	common.ExtCompliance100gSwdm4:     "100G SWDM4",
+	common.ExtCompliance100gPam4Bidi:  "100G PAM4 BiDi",
+	common.ExtCompliance4wdm10:        "4WDM-10 MSA (10km version of 100G CWDM4 with same RS(528,514) FEC in host system)",
+	common.ExtCompliance4wdm20:        "4WDM-20 MSA (20km version of 100GBASE-LR4 with RS(528,514) FEC in host system)",
+	common.ExtCompliance4wdm40:        "4WDM-40 MSA (40km reach with APD receiver and RS(528,514) FEC in host system)",
+	common.ExtCompliance100gBaseDr:    "100GBASE-DR (Clause 140), CAUI-4 (no FEC)",
+	common.ExtCompliance100gFr:        "100G-FR or 100GBASE-FR1 (Clause 140), CAUI-4 (no FEC)",
+	common.ExtCompliance100gLr:        "100G-LR or 100GBASE-LR1 (Clause 140), CAUI-4 (no FEC)",
+	common.ExtComplianceAcc50gaui1e6:  "Active Copper Cable with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M. Providing a worst BER of 10-6 or below",
+	common.ExtComplianceAoc50gaui1e6:  "Active Optical Cable with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M. Providing a worst BER of 10-6 or below",
+	common.ExtComplianceAcc50gaui26e4: "Active Copper Cable with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M. Providing a worst BER of 2.6x10-4 for ACC, 10-5 for AUI, or below",
+	common.ExtComplianceAoc50gaui26e4: "Active Optical Cable with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M. Providing a worst BER of 2.6x10-4 for AOC, 10-5 for AUI, or below",
+	common.ExtCompliance50gBaseCr:     "50GBASE-CR, 100GBASE-CR2, or 200GBASE-CR4",
+	common.ExtCompliance50gBaseSr:     "50GBASE-SR, 100GBASE-SR2, or 200GBASE-SR4",
+	common.ExtCompliance50gBaseFr:     "50GBASE-FR or 200GBASE-DR4",
+	common.ExtCompliance200gBaseFr4:   "200GBASE-FR4",
+	common.ExtCompliance200gPsm4:      "200G 1550 nm PSM4",
+	common.ExtCompliance50gBaseLr:     "50GBASE-LR",
+	common.ExtCompliance200gBaseLr4:   "200GBASE-LR4",
+	0x50:                              "64GFC EA",
+	0x51:                              "64GFC SW",
+	0x52:                              "64GFC LW",
+	0x53:                              "128GFC EA",
+	0x54:                              "128GFC SW",
+	0x55:                              "128GFC LW",
+}
+
+// ethtoolOptions lists the option bits (bytes 64-65) in ethtool's order
+var ethtoolOptions = []struct {
+	byte int
+	mask byte
+	name string
+}{
+	{1, 1 << 1, "RX_LOS implemented"},
+	{1, 1 << 2, "RX_LOS implemented, inverted"},
+	{1, 1 << 3, "TX_FAULT implemented"},
+	{1, 1 << 4, "TX_DISABLE implemented"},
+	{1, 1 << 5, "RATE_SELECT implemented"},
+	{1, 1 << 6, "Tunable transmitter technology"},
+	{1, 1 << 7, "Receiver decision threshold implemented"},
+	{0, 1 << 0, "Linear receiver output implemented"},
+	{0, 1 << 1, "Power level 2 requirement"},
+	{0, 1 << 2, "Cooled transceiver implemented"},
+	{0, 1 << 3, "Retimer or CDR implemented"},
+	{0, 1 << 4, "Paging implemented"},
+	{0, 1 << 5, "Power level 3 requirement"},
+}
+
+// ethtoolFlags lists the A2h alarm and warning flags in ethtool's order
+var ethtoolFlags = []struct {
+	name    string
+	warning bool
+	byte    int
+	mask    byte
+}{
+	{"Laser bias current high alarm", false, 0, 1 << 3},
+	{"Laser bias current low alarm", false, 0, 1 << 2},
+	{"Laser bias current high warning", true, 0, 1 << 3},
+	{"Laser bias current low warning", true, 0, 1 << 2},
+	{"Laser output power high alarm", false, 0, 1 << 1},
+	{"Laser output power low alarm", false, 0, 1 << 0},
+	{"Laser output power high warning", true, 0, 1 << 1},
+	{"Laser output power low warning", true, 0, 1 << 0},
+	{"Module temperature high alarm", false, 0, 1 << 7},
+	{"Module temperature low alarm", false, 0, 1 << 6},
+	{"Module temperature high warning", true, 0, 1 << 7},
+	{"Module temperature low warning", true, 0, 1 << 6},
+	{"Module voltage high alarm", false, 0, 1 << 5},
+	{"Module voltage low alarm", false, 0, 1 << 4},
+	{"Module voltage high warning", true, 0, 1 << 5},
+	{"Module voltage low warning", true, 0, 1 << 4},
+	{"Laser rx power high alarm", false, 1, 1 << 7},
+	{"Laser rx power low alarm", false, 1, 1 << 6},
+	{"Laser rx power high warning", true, 1, 1 << 7},
+	{"Laser rx power low warning", true, 1, 1 << 6},
+}
+
+func onOff(b bool) string {
+	if b {
+		return "On"
+	}
+	return "Off"
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
+
+// ethtoolTransceiverList returns the transceiver types in ethtool's order,
+// byte by byte starting with the most significant bit
+func (s *Sff8079) ethtoolTransceiverList() []string {
+	var l []string
+	for i := 0; i < len(s.Transceiver); i++ {
+		for bit := 7; bit >= 0; bit-- {
+			k := uint64(1) << (8*i + bit)
+			if n, ok := transceiverNames[k]; ok && s.Transceiver.Uint64()&k != 0 {
+				l = append(l, n)
+			}
+		}
+	}
+	if n, ok := ethtoolExtComplianceNames[s.TranscComp]; ok {
+		l = append(l, "Extended: "+n)
+	}
+	return l
+}
+
+func (s *Sff8079) ethtoolExtIdentifier() string {
+	id := byte(s.ExtIdentifier)
+	switch {
+	case id == 0x00:
+		return "0x00 (GBIC not specified / not MOD_DEF compliant)"
+	case id == 0x04:
+		return "0x04 (GBIC/SFP defined by 2-wire interface ID)"
+	case id <= 0x07:
+		return fmt.Sprintf("0x%02x (GBIC compliant with MOD_DEF %d)", id, id)
+	}
+	return fmt.Sprintf("0x%02x (unknown)", id)
+}
+
+// ethtoolBitRate returns the nominal bit rate in MBd and the upper and lower
+// margins in percent. A nominal rate of FFh selects byte 66 as the rate in
+// units of 250 MBd, with byte 67 as both margins.
+func (s *Sff8079) ethtoolBitRate() (nominal, max, min int) {
+	switch br := int(s.BrNominal); br {
+	case 0:
+		return 0, 0, 0
+	case 0xff:
+		return int(s.BrMax) * 250, int(s.BrMin), int(s.BrMin)
+	default:
+		return br * 100, int(s.BrMax), int(s.BrMin)
+	}
+}
+
+func (s *Sff8079) ethtoolWavelength() string {
+	switch {
+	case s.IsPassiveCable():
+		names := map[byte]string{0x00: "unspecified", 0x01: "SFF-8431 appendix E"}
+		return common.EthtoolLine("Passive Cu cmplnce.", cableCompliance(s.LaserWavelength[0], names))
+	case s.IsActiveCable():
+		names := map[byte]string{0x00: "unspecified", 0x01: "SFF-8431 appendix E", 0x04: "SFF-8431 limiting"}
+		return common.EthtoolLine("Active Cu cmplnce.", cableCompliance(s.LaserWavelength[0], names))
+	}
+	return common.EthtoolLine("Laser wavelength", fmt.Sprintf("%dnm", int(s.LaserWavelength[0])<<8|int(s.LaserWavelength[1])))
+}
+
+func cableCompliance(b byte, names map[byte]string) string {
+	n, ok := names[b]
+	if !ok {
+		n = "unknown"
+	}
+	return fmt.Sprintf("0x%02x (%s) [SFF-8472 rev10.4 only]", b, n)
+}
+
+// EthtoolString renders the module like "ethtool -m", with the same field
+// names, units and order. Diagnostics are included if A2h was read.
+func (s *Sff8079) EthtoolString() string {
+	var b strings.Builder
+	line := func(label, value string) {
+		b.WriteString(common.EthtoolLine(label, value))
+	}
+	withUnit := func(label string, v byte, mult int, unit string) {
+		line(label, fmt.Sprintf("%d%s", int(v)*mult, unit))
+	}
+
+	t := s.Transceiver
+	nominal, max, min := s.ethtoolBitRate()
+	rate, ok := rateIdentifierNames[s.RateIdentifier]
+	if !ok {
+		rate = "reserved or unknown"
+	}
+
+	line("Identifier", s.Identifier.EthtoolString())
+	line("Extended identifier", s.ethtoolExtIdentifier())
+	line("Connector", s.Connector.EthtoolString())
+	line("Transceiver codes", fmt.Sprintf("0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x",
+		t[0], t[1], t[2], t[3], t[4], t[5], t[6], t[7], byte(s.TranscComp)))
+	b.WriteString(common.EthtoolLines("Transceiver type", s.ethtoolTransceiverList()))
+	line("Encoding", s.Encoding.EthtoolString())
+	line("BR, Nominal", fmt.Sprintf("%dMBd", nominal))
+	line("Rate identifier", fmt.Sprintf("0x%02x (%s)", s.RateIdentifier, rate))
+	withUnit("Length (SMF,km)", byte(s.LengthSmfKm), 1, "km")
+	withUnit("Length (SMF)", byte(s.LengthSmfM), 100, "m")
+	withUnit("Length (50um)", byte(s.Length50umM), 10, "m")
+	withUnit("Length (62.5um)", byte(s.Length625umM), 10, "m")
+	withUnit("Length (Copper)", byte(s.LengthCopper), 1, "m")
+	withUnit("Length (OM3)", byte(s.LengthOm3), 10, "m")
+	b.WriteString(s.ethtoolWavelength())
+	line("Vendor name", common.EthtoolASCII(s.Vendor[:]))
+	line("Vendor OUI", s.VendorOui.EthtoolString())
+	line("Vendor PN", common.EthtoolASCII(s.VendorPn[:]))
+	line("Vendor rev", common.EthtoolASCII(s.VendorRev[:]))
+	line("Option values", fmt.Sprintf("0x%02x 0x%02x", s.Options[0], s.Options[1]))
+	for _, o := range ethtoolOptions {
+		if s.Options[o.byte]&o.mask != 0 {
+			line("Option", o.name)
+		}
+	}
+	line("BR margin, max", fmt.Sprintf("%d%%", max))
+	line("BR margin, min", fmt.Sprintf("%d%%", min))
+	line("Vendor SN", common.EthtoolASCII(s.VendorSn[:]))
+	line("Date code", common.EthtoolASCII(s.DateCode[:]))
+
+	if s.hasA2h() {
+		b.WriteString(s.ethtoolDiagnostics())
+	}
+	return b.String()
+}
+
+// ethtoolDiagnostics renders the A2h diagnostics like ethtool
+func (s *Sff8079) ethtoolDiagnostics() string {
+	var b strings.Builder
+	line := func(label, value string) {
+		b.WriteString(common.EthtoolLine(label, value))
+	}
+
+	line("Optical diagnostics support", yesNo(s.DiagMonitType.IsDdmImplemented()))
+	if !s.DiagMonitType.IsDdmImplemented() {
+		return b.String()
+	}
+
+	rx := "Receiver signal OMA"
+	if s.DiagMonitType.IsReceivedPowerAverage() {
+		rx = "Receiver signal average optical power"
+	}
+	line("Laser bias current", s.TxBias.EthtoolString())
+	line("Laser output power", s.TxPower.EthtoolString())
+	line(rx, s.RxPower.EthtoolString())
+	line("Module temperature", s.Temperature.EthtoolString())
+	line("Module voltage", s.Vcc.EthtoolString())
+
+	alarms := s.EnhancedOpts.IsAlarmWarningFlagsImplemented()
+	line("Alarm/warning flags implemented", yesNo(alarms))
+	if !alarms {
+		return b.String()
+	}
+	for _, f := range ethtoolFlags {
+		flags := s.Alarms
+		if f.warning {
+			flags = s.Warnings
+		}
+		line(f.name, onOff(flags[f.byte]&f.mask != 0))
+	}
+	b.WriteString(common.EthtoolThresholds(s.TxBiasThresholds, s.TxPowerThresholds, s.TempThresholds, s.VccThresholds, s.RxPowerThresholds))
+	return b.String()
+}
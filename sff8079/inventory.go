@@ -0,0 +1,73 @@
+package sff8079
+
+import "github.com/bluecmd/go-sff/common"
+
+// Inventory returns the vendor independent identification of the module
+func (s *Sff8079) Inventory() common.Inventory {
+	inv := common.Inventory{
+		Identifier:   s.Identifier,
+		Vendor:       s.Vendor.String(),
+		OUI:          s.VendorOui,
+		PartNumber:   s.VendorPn.String(),
+		Revision:     s.VendorRev.String(),
+		SerialNumber: s.VendorSn.String(),
+		DateCode:     s.DateCode,
+		Connector:    s.Connector,
+		BitRate:      int(s.BrNominal) * 100,
+		LaneCount:    1,
+		MediaType:    s.mediaType(),
+		Reach: common.Reach{
+			Smf: float64(s.LengthSmfKm) * 1000,
+			Om2: float64(s.Length50umM) * 10,
+			Om1: float64(s.Length625umM) * 10,
+			Om3: float64(s.LengthOm3) * 10,
+		},
+	}
+	// Byte 12 FFh means the bit rate is in byte 66, in units of 250 Mb/s
+	if s.BrNominal == 0xFF {
+		inv.BitRate = int(s.BrMax) * 250
+	}
+	// Byte 15 has a finer granularity than byte 14, but stops at 25.4 km
+	if m := float64(s.LengthSmfM) * 100; m > inv.Reach.Smf {
+		inv.Reach.Smf = m
+	}
+	// Byte 18 is the cable length in m for cable assemblies and the OM4
+	// length in units of 10 m otherwise
+	if s.IsPassiveCable() || s.IsActiveCable() {
+		inv.Reach.Copper = float64(s.LengthCopper)
+	} else {
+		inv.Reach.Om4 = float64(s.LengthCopper) * 10
+		inv.Wavelength = float64(s.LaserWavelength.Nanometers())
+	}
+	return inv
+}
+
+// mediaType derives the media type from the transceiver compliance codes and
+// the supported link lengths
+func (s *Sff8079) mediaType() common.MediaType {
+	switch {
+	case s.IsPassiveCable():
+		return common.MediaTypePassiveCopper
+	case s.IsActiveCable():
+		return common.MediaTypeActiveCable
+	case s.isBaseT():
+		return common.MediaTypeBaseT
+	case s.LengthSmfKm != 0 || s.LengthSmfM != 0:
+		return common.MediaTypeSmf
+	case s.Length50umM != 0 || s.Length625umM != 0 || s.LengthOm3 != 0 || s.LengthCopper != 0:
+		return common.MediaTypeMmf
+	}
+	return common.MediaTypeUndefined
+}
+
+func (s *Sff8079) isBaseT() bool {
+	if s.Transceiver.Uint64()&(Ether1000BaseT|FcCopperBaseT) != 0 {
+		return true
+	}
+	switch s.TranscComp {
+	case common.ExtCompliance10gBaseTSfi, common.ExtCompliance10gBaseTSr,
+		common.ExtCompliance5gBaseT, common.ExtCompliance2500BaseT:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package sff8079
+
+import (
+	"testing"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+func TestInventoryCable(t *testing.T) {
+	b := testEeprom(0x68)
+	b[8] = 0x04  // Passive cable
+	b[12] = 0xFF // Bit rate in byte 66
+	b[18] = 3    // 3 m cable
+	b[60] = 0x01 // SFF-8431 Appendix E
+	b[66] = 0x67 // 25750 Mb/s
+
+	s, err := Decode(b)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	inv := s.Inventory()
+	if inv.MediaType != common.MediaTypePassiveCopper {
+		t.Errorf("MediaType = %s, want passive copper", inv.MediaType)
+	}
+	if inv.BitRate != 25750 {
+		t.Errorf("BitRate = %d, want 25750", inv.BitRate)
+	}
+	if inv.Wavelength != 0 || inv.Reach != (common.Reach{Copper: 3}) {
+		t.Errorf("Wavelength = %g, Reach = %+v, want copper cable of 3 m", inv.Wavelength, inv.Reach)
+	}
+}
@@ -9,72 +9,149 @@ import (
 )
 
 const (
-	red     = "\x1b[31m"
-	green   = "\x1b[32m"
-	yellow  = "\x1b[33m"
-	blue    = "\x1b[34m"
-	magenta = "\x1b[35m"
-	cyan    = "\x1b[36m"
-	white   = "\x1b[37m"
-	clear   = "\x1b[0m"
+	red     = common.ColorRed
+	green   = common.ColorGreen
+	yellow  = common.ColorYellow
+	blue    = common.ColorBlue
+	magenta = common.ColorMagenta
+	cyan    = common.ColorCyan
+	white   = common.ColorWhite
+	clear   = common.ColorClear
 )
 
 type Sff8079 struct {
-	Identifier      common.Identifier   `json:"identifier"`     // 0 - Identifier
-	ExtIdentifier   ExtIdentifier       `json:"extIdentifier"`  // 1 - Ext. Identifier
-	Connector       common.Connector    `json:"connector"`      // 2 - Connector
-	Transceiver     Transceiver         `json:"transceiver"`    // 3-10 - Transceiver
-	Encoding        Encoding            `json:"encoding"`       // 11 - Encoding
-	BrNominal       common.Value100Mbps `json:"brNominal"`      // 12 - BR Nominal
-	RateIdentifier  byte                `json:"rateIdentifier"` // 13 - Rate ID
-	LengthSmfKm     common.ValueKm      `json:"lengthSmfKm"`    // 14 - Length(9μm) - km - (SMF)?
-	LengthSmfM      common.ValueM       `json:"lengthSmfM"`     // 15 - Length (9μm) - (SMF)?
-	Length50umM     common.ValueM       `json:"length50umM"`    // 16 - Length (50μm)
-	Length625umM    common.ValueM       `json:"length625umM"`   // 17 - Length (62.5um)
-	LengthCopper    common.ValueM       `json:"lengthCopper"`   // 18 - Length (Copper)
-	LengthOm3       common.ValueM       `json:"lengthOm3"`      // 19 - Length (50μm)
-	Vendor          common.String16     `json:"vendor"`         // 20-35 - Vendor name
-	TranscComp      byte                `json:"-"`              // 36 - Transciever
-	VendorOui       common.VendorOUI    `json:"vendorOui"`      // 37-39 - Vendor OUI
-	VendorPn        common.String16     `json:"vendorPn"`       // 40-55 - Vendor PN
-	VendorRev       common.String4      `json:"vendorRev"`      // 56-59 - Vendor rev
-	LaserWavelength [2]byte             `json:"-"`              // 60-61 - Laser wavelength
-	Unallocated     byte                `json:"-"`              // 62 - Unallocated
-	CcBase          byte                `json:"-"`              // 63 - CC_BASE
-	Options         Options             `json:"options"`        // 64-65 - Options
-	BrMax           common.ValuePerc    `json:"brMax"`          // 66 - BR, max
-	BrMin           common.ValuePerc    `json:"brMin"`          // 67 - BR, min
-	VendorSn        common.String16     `json:"vendorSn"`       // 68-83 - Vendor SN
-	DateCode        common.DateCode     `json:"dateCode"`       // 84-91 - Date code
-	DiagMonitType   byte                `json:"-"`              // 92 - Diagnostic Monitoring Type
-	EnhancedOpts    byte                `json:"-"`              // 93 - Enhanced Options
-	Sff8472Comp     byte                `json:"-"`              // 94 - SFF-8472 Compliance
-	CcExt           byte                `json:"-"`              // 95 - CC_EXT
-	VendorSpec1     [24]byte            `json:"-"`              // 96-119 - Vendor Specific 1
-	VendorAristaSa  byte                `json:"vendorSa"`       // 120 Vendor Arista SA
-	VendorSpec2     [7]byte             `json:"-"`              // 121-127 - Vendor Specific 2
-	Reserved        [128]byte           `json:"-"`              // 128-255 - Reserved
+	Identifier      common.Identifier         `json:"identifier"`               // 0 - Identifier
+	ExtIdentifier   ExtIdentifier             `json:"extIdentifier"`            // 1 - Ext. Identifier
+	Connector       common.Connector          `json:"connector"`                // 2 - Connector
+	Transceiver     Transceiver               `json:"transceiver"`              // 3-10 - Transceiver
+	Encoding        Encoding                  `json:"encoding"`                 // 11 - Encoding
+	BrNominal       common.Value100Mbps       `json:"brNominal"`                // 12 - BR Nominal
+	RateIdentifier  byte                      `json:"rateIdentifier"`           // 13 - Rate ID
+	LengthSmfKm     common.ValueKm            `json:"lengthSmfKm"`              // 14 - Length(9μm) - km - (SMF)?
+	LengthSmfM      common.ValueM             `json:"lengthSmfM"`               // 15 - Length (9μm) - (SMF)?
+	Length50umM     common.ValueM             `json:"length50umM"`              // 16 - Length (50μm)
+	Length625umM    common.ValueM             `json:"length625umM"`             // 17 - Length (62.5um)
+	LengthCopper    common.ValueM             `json:"lengthCopper"`             // 18 - Length (Copper)
+	LengthOm3       common.ValueM             `json:"lengthOm3"`                // 19 - Length (50μm)
+	Vendor          common.String16           `json:"vendor"`                   // 20-35 - Vendor name
+	TranscComp      common.ExtendedCompliance `json:"transcComp"`               // 36 - Extended Specification Compliance Codes
+	VendorOui       common.VendorOUI          `json:"vendorOui"`                // 37-39 - Vendor OUI
+	VendorPn        common.String16           `json:"vendorPn"`                 // 40-55 - Vendor PN
+	VendorRev       common.String4            `json:"vendorRev"`                // 56-59 - Vendor rev
+	LaserWavelength LaserWavelength           `json:"laserWavelength"`          // 60-61 - Laser wavelength or cable compliance
+	Unallocated     byte                      `json:"-"`                        // 62 - Unallocated
+	CcBase          byte                      `json:"-"`                        // 63 - CC_BASE
+	Options         Options                   `json:"options"`                  // 64-65 - Options
+	BrMax           common.ValuePerc          `json:"brMax"`                    // 66 - BR, max
+	BrMin           common.ValuePerc          `json:"brMin"`                    // 67 - BR, min
+	VendorSn        common.String16           `json:"vendorSn"`                 // 68-83 - Vendor SN
+	DateCode        common.DateCode           `json:"dateCode"`                 // 84-91 - Date code
+	DiagMonitType   DiagnosticMonitoringType  `json:"diagnosticMonitoringType"` // 92 - Diagnostic Monitoring Type
+	EnhancedOpts    EnhancedOptions           `json:"enhancedOptions"`          // 93 - Enhanced Options
+	Sff8472Comp     Sff8472Compliance         `json:"sff8472Compliance"`        // 94 - SFF-8472 Compliance
+	CcExt           byte                      `json:"-"`                        // 95 - CC_EXT
+	VendorSpec1     [24]byte                  `json:"-"`                        // 96-119 - Vendor Specific 1
+	VendorAristaSa  byte                      `json:"vendorSa"`                 // 120 Vendor Arista SA
+	VendorSpec2     [7]byte                   `json:"-"`                        // 121-127 - Vendor Specific 2
+	Reserved        [128]byte                 `json:"-"`                        // 128-255 - Reserved
 	// Address A2h
-	A2hReserved0 [96]byte                 `json:"-"`           // 0-95 - Reserved
-	Temperature  common.TemperatureQ8_8BE `json:"temperature"` // 96-97 - Internally measured module temperature
-	Vcc          common.VoltageVoltBE     `json:"vcc"`         // 98-99 - Internally measured supply voltage in transceiver
-	TxBias       common.CurrentMilliAmpBE `json:"txBias"`      // 100-101 - Internally measured TX Bias Current
-	TxPower      common.PowerMilliWattBE  `json:"txPower"`     // 102-103 - Measured TX output power
-	RxPower      common.PowerMilliWattBE  `json:"rxPower"`     // 104-105 - Measured RX input power
-	A2hReserved1 [22]byte                 `json:"-"`           // 106-127 - Reserved
+	TempThresholds    common.TemperatureThresholds `json:"tempThresholds"`    // 0-7 - Temperature alarm and warning thresholds
+	VccThresholds     common.VoltageThresholds     `json:"vccThresholds"`     // 8-15 - Voltage alarm and warning thresholds
+	TxBiasThresholds  common.CurrentThresholds     `json:"txBiasThresholds"`  // 16-23 - TX Bias alarm and warning thresholds
+	TxPowerThresholds common.PowerThresholds       `json:"txPowerThresholds"` // 24-31 - TX Power alarm and warning thresholds
+	RxPowerThresholds common.PowerThresholds       `json:"rxPowerThresholds"` // 32-39 - RX Power alarm and warning thresholds
+	A2hReserved0      [16]byte                     `json:"-"`                 // 40-55 - Optional laser temperature and TEC current thresholds
+	Calibration       Calibration                  `json:"calibration"`       // 56-91 - External calibration constants
+	A2hUnallocated    [3]byte                      `json:"-"`                 // 92-94 - Unallocated
+	CcDmi             byte                         `json:"-"`                 // 95 - CC_DMI
+	Temperature       common.TemperatureQ8_8BE     `json:"temperature"`       // 96-97 - Internally measured module temperature
+	Vcc               common.VoltageVoltBE         `json:"vcc"`               // 98-99 - Internally measured supply voltage in transceiver
+	TxBias            common.CurrentMilliAmpBE     `json:"txBias"`            // 100-101 - Internally measured TX Bias Current
+	TxPower           common.PowerMilliWattBE      `json:"txPower"`           // 102-103 - Measured TX output power
+	RxPower           common.PowerMilliWattBE      `json:"rxPower"`           // 104-105 - Measured RX input power
+	A2hOptional       [4]byte                      `json:"-"`                 // 106-109 - Optional laser temperature and TEC current
+	StatusControl     StatusControl                `json:"statusControl"`     // 110 - Optional status/control bits
+	A2hReserved1      byte                         `json:"-"`                 // 111 - Reserved
+	Alarms            DiagFlags                    `json:"alarms"`            // 112-113 - Alarm flags
+	TxInputEq         byte                         `json:"-"`                 // 114 - Tx input equalization control
+	RxOutputEmph      byte                         `json:"-"`                 // 115 - Rx output emphasis control
+	Warnings          DiagFlags                    `json:"warnings"`          // 116-117 - Warning flags
+	ExtStatusControl  [2]byte                      `json:"-"`                 // 118-119 - Extended module control/status
+	A2hVendorSpec     [7]byte                      `json:"-"`                 // 120-126 - Vendor specific
+	PageSelect        byte                         `json:"-"`                 // 127 - Page select
+	// Decoded, not part of the memory map
+	CalibrationMode CalibrationMode `json:"calibrationMode"`           // Calibration applied to the A2h diagnostics
+	CableCompliance []string        `json:"cableCompliance,omitempty"` // Byte 60 cable compliance of copper cables
+
+	regions []common.Region
+	image   []byte // Flat dump s was decoded from, see Encode
 }
 
+const (
+	eepromLen = 512       // Size of an A0h and A2h dump
+	mappedLen = 256 + 128 // A0h and the A2h lower page mapped by Sff8079
+)
+
+// Decode decodes an A0h dump of 256 bytes, optionally followed by A2h (lower
+// page or both pages). eeprom is copied, so the caller may reuse it.
+// Externally calibrated diagnostics are converted to calibrated values, see
+// CalibrationMode.
 func Decode(eeprom []byte) (*Sff8079, error) {
-	if len(eeprom) < 512 {
-		return nil, fmt.Errorf("eeprom size to small needs to be 512 bytes or larger got: %d bytes", len(eeprom))
+	m, err := common.ParseFlatSff8079(eeprom)
+	if err != nil {
+		return nil, err
 	}
+	return DecodeMemory(m)
+}
+
+var (
+	ccBase = common.CheckCode{Name: "CC_BASE", Start: 0, Offset: 63}
+	ccExt  = common.CheckCode{Name: "CC_EXT", Start: 64, Offset: 95}
+)
+
+// bytes relies on the memory map filling exactly the first mappedLen bytes of
+// Sff8079, with the decoded fields after it. Fails to compile otherwise.
+var _ [mappedLen]byte = [unsafe.Offsetof(Sff8079{}.CalibrationMode)]byte{}
+
+// bytes returns the A0h and A2h memory map backing s
+func (s *Sff8079) bytes() []byte {
+	return (*[mappedLen]byte)(unsafe.Pointer(s))[:]
+}
+
+// Validate verifies the CC_BASE (byte 63) and CC_EXT (byte 95) check codes.
+// Mismatches are reported as *common.ChecksumError, or common.ChecksumErrors
+// if both check codes are wrong.
+func (s *Sff8079) Validate() error {
+	return common.VerifyCheckCodes(s.bytes(), ccBase, ccExt)
+}
 
-	if (eeprom[0] == 2 || eeprom[0] == 3 || eeprom[0] == 0xb) && eeprom[1] == 4 {
-		sff := (*Sff8079)(unsafe.Pointer(&eeprom[0]))
-		return sff, nil
+// UpdateChecksums recomputes CC_BASE and CC_EXT from the current contents
+func (s *Sff8079) UpdateChecksums() {
+	ccBase.Update(s.bytes())
+	ccExt.Update(s.bytes())
+}
+
+// DecodeMemory decodes A0h and the A2h lower page from paged module memory.
+// A2h reads as zeros if it is not present, see Regions.
+func DecodeMemory(m *common.Memory) (*Sff8079, error) {
+	if !m.Has(common.Region{Address: common.AddressA0, Offset: 0, Length: 2 * common.PageLen}) {
+		return nil, fmt.Errorf("A0h not present")
+	}
+	b := m.FlatSff8079()
+	if !(b[0] == 2 || b[0] == 3 || b[0] == 0xb) || b[1] != 4 {
+		return nil, fmt.Errorf("unknown eeprom standard, identifier: 0x%02x", b[0])
 	}
 
-	return nil, fmt.Errorf("unknown eeprom standard, identifier: 0x%02x", byte(eeprom[0]))
+	s := &Sff8079{regions: m.Regions(), image: b[:flatLen(m)]}
+	copy(s.bytes(), b)
+	s.calibrate()
+	s.CableCompliance = s.cableCompliance()
+	return s, nil
+}
+
+// Regions returns the half pages present in the memory s was decoded from
+func (s *Sff8079) Regions() []common.Region {
+	return s.regions
 }
 
 func (s *Sff8079) String() string {
@@ -82,7 +159,7 @@ func (s *Sff8079) String() string {
 		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Extended Identifier [1]", byte(s.ExtIdentifier), s.ExtIdentifier) +
 		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Connector [2]", byte(s.Connector), s.Connector) +
 		fmt.Sprintf("%-50s : 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x\n", "Transceiver Codes [3-10]", s.Transceiver[0], s.Transceiver[1], s.Transceiver[2], s.Transceiver[3], s.Transceiver[4], s.Transceiver[5], s.Transceiver[6], s.Transceiver[7]) +
-		fmt.Sprintf("%-50s : %s\n", "Transceiver Type", strings.Join(s.Transceiver.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s\n", "Transceiver Type", strings.Join(s.TransceiverList(), fmt.Sprintf("\n%-50s : ", " "))) +
 		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Encoding [11]", byte(s.Encoding), s.Encoding) +
 		fmt.Sprintf("%-50s : %s\n", "BR, Nominal [12]", s.BrNominal) +
 		fmt.Sprintf("%-50s : 0x%02x\n", "Rate Identifier [13]", s.RateIdentifier) +
@@ -96,11 +173,17 @@ func (s *Sff8079) String() string {
 		fmt.Sprintf("%-50s : %s\n", "Vendor OUI [37-39]", s.VendorOui) +
 		fmt.Sprintf("%-50s : %s\n", "Vendor PN [40-55]", s.VendorPn) +
 		fmt.Sprintf("%-50s : %s\n", "Vendor Rev [56-59]", s.VendorRev) +
+		fmt.Sprintf("%-50s : %s\n", s.wavelengthLabel(), s.wavelengthString()) +
+		fmt.Sprintf("%-50s : %s\n", "CC_BASE [63]", ccBase.Status(s.bytes())) +
 		fmt.Sprintf("%-50s : %s\n", "Option Values [64-65]", s.Options.String()) +
 		fmt.Sprintf("%-50s : %s\n", "BR Margin, Max [66]", s.BrMax) +
 		fmt.Sprintf("%-50s : %s\n", "BR Margin, Min [67]", s.BrMin) +
 		fmt.Sprintf("%-50s : %s\n", "Vendor SN [68-83]", s.VendorSn) +
-		fmt.Sprintf("%-50s : %s\n", "Date Code [84-91]", s.DateCode)
+		fmt.Sprintf("%-50s : %s\n", "Date Code [84-91]", s.DateCode) +
+		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Diagnostic Monitoring Type [92]", byte(s.DiagMonitType), s.DiagMonitType) +
+		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Enhanced Options [93]", byte(s.EnhancedOpts), s.EnhancedOpts) +
+		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "SFF-8472 Compliance [94]", byte(s.Sff8472Comp), s.Sff8472Comp) +
+		fmt.Sprintf("%-50s : %s\n", "CC_EXT [95]", ccExt.Status(s.bytes()))
 
 	if s.Vendor.String() == "Arista Networks" && strings.HasPrefix(s.VendorPn.String(), "CAB-Q-S-") {
 		str += fmt.Sprintf("%-50s : %x\n", "Vendor SA [120]", s.VendorAristaSa)
@@ -111,15 +194,88 @@ func (s *Sff8079) String() string {
 		fmt.Sprintf("%-50s : %s\n", "Vcc [A2h 98-99]", s.Vcc) +
 		fmt.Sprintf("%-50s : %s\n", "TX Bias [A2h 100-101]", s.TxBias) +
 		fmt.Sprintf("%-50s : %s\n", "TX Power [A2h 102-103]", s.TxPower) +
-		fmt.Sprintf("%-50s : %s\n", "RX Power [A2h 104-105]", s.RxPower)
+		fmt.Sprintf("%-50s : %s\n", "RX Power [A2h 104-105]", s.RxPower) +
+		fmt.Sprintf("%-50s : %s\n", "Status/Control [A2h 110]", s.StatusControl) +
+		fmt.Sprintf("%-50s : %s\n", "Alarm Flags [A2h 112-113]", s.Alarms) +
+		fmt.Sprintf("%-50s : %s\n", "Warning Flags [A2h 116-117]", s.Warnings)
+
+	for _, t := range s.thresholds() {
+		str += fmt.Sprintf("%-50s : %s\n", t[0], t[1])
+	}
 
 	return str
 }
 
+// TransceiverList returns the transceiver compliance codes (bytes 3-10)
+// followed by the extended specification compliance code (byte 36), if any
+func (s *Sff8079) TransceiverList() []string {
+	l := s.Transceiver.List()
+	if s.TranscComp.IsSpecified() {
+		l = append(l, "Extended: "+s.TranscComp.String())
+	}
+	return l
+}
+
+// IsPassiveCable returns true for passive copper cables (byte 8 bit 2)
+func (s *Sff8079) IsPassiveCable() bool {
+	return s.Transceiver.Uint64()&PassiveCable != 0
+}
+
+// IsActiveCable returns true for active copper cables (byte 8 bit 3)
+func (s *Sff8079) IsActiveCable() bool {
+	return s.Transceiver.Uint64()&ActiveCable != 0
+}
+
+// cableCompliance returns the cable compliance of copper cables, or nil for
+// modules with a laser wavelength in bytes 60-61
+func (s *Sff8079) cableCompliance() []string {
+	switch {
+	case s.IsPassiveCable():
+		return append([]string{}, s.LaserWavelength.PassiveCompliance()...)
+	case s.IsActiveCable():
+		return append([]string{}, s.LaserWavelength.ActiveCompliance()...)
+	}
+	return nil
+}
+
+func (s *Sff8079) wavelengthLabel() string {
+	switch {
+	case s.IsPassiveCable():
+		return "Passive Cu Compliance [60]"
+	case s.IsActiveCable():
+		return "Active Cu Compliance [60]"
+	}
+	return "Laser Wavelength [60-61]"
+}
+
+func (s *Sff8079) wavelengthString() string {
+	if !s.IsPassiveCable() && !s.IsActiveCable() {
+		return s.LaserWavelength.String()
+	}
+	c := s.cableCompliance()
+	if len(c) == 0 {
+		return fmt.Sprintf("0x%02x (Unspecified)", s.LaserWavelength[0])
+	}
+	return fmt.Sprintf("0x%02x (%s)", s.LaserWavelength[0], strings.Join(c, ", "))
+}
+
 func strCol(k string, v string, c1 string, c2 string) string {
 	return fmt.Sprintf("%s%-50s%s : %s%s%s\n", c1, k, clear, c2, v, clear)
 }
 
+// checkCodeColor returns red for mismatching check codes
+func checkCodeColor(c common.CheckCode, b []byte) string {
+	if c.Verify(b) != nil {
+		return red
+	}
+	return green
+}
+
+// strColRaw is strCol for values that carry their own colors
+func strColRaw(k string, v string, c1 string) string {
+	return fmt.Sprintf("%s%-50s%s : %s\n", c1, k, clear, v)
+}
+
 func joinStrCol(k string, l []string, c1 string, c2 string) string {
 	if len(l) < 1 {
 		return ""
@@ -137,7 +293,7 @@ func (s *Sff8079) StringCol() string {
 		strCol("Extended Identifier [1]", fmt.Sprintf("0x%02x (%s)", byte(s.ExtIdentifier), s.ExtIdentifier), cyan, green) +
 		strCol("Connector [2]", fmt.Sprintf("0x%02x (%s)", byte(s.Connector), s.Connector), cyan, green) +
 		strCol("Transceiver Codes [3-10]", fmt.Sprintf("0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x", s.Transceiver[0], s.Transceiver[1], s.Transceiver[2], s.Transceiver[3], s.Transceiver[4], s.Transceiver[5], s.Transceiver[6], s.Transceiver[7]), cyan, green) +
-		joinStrCol("Transceiver Type", s.Transceiver.List(), cyan, yellow) +
+		joinStrCol("Transceiver Type", s.TransceiverList(), cyan, yellow) +
 		strCol("Encoding [11]", fmt.Sprintf("0x%02x (%s)", byte(s.Encoding), s.Encoding), cyan, green) +
 		strCol("BR, Nominal [12]", s.BrNominal.String(), cyan, green) +
 		strCol("Rate Identifier [13]", fmt.Sprintf("0x%02x", s.RateIdentifier), cyan, green) +
@@ -151,11 +307,17 @@ func (s *Sff8079) StringCol() string {
 		strCol("Vendor OUI [37-39]", s.VendorOui.String(), cyan, green) +
 		strCol("Vendor PN [40-55]", s.VendorPn.String(), cyan, green) +
 		strCol("Vendor Rev [56-59]", s.VendorRev.String(), cyan, green) +
+		strCol(s.wavelengthLabel(), s.wavelengthString(), cyan, green) +
+		strCol("CC_BASE [63]", ccBase.Status(s.bytes()), cyan, checkCodeColor(ccBase, s.bytes())) +
 		strCol("Option Values [64-65]", s.Options.String(), cyan, green) +
 		strCol("BR Margin, Max [66]", s.BrMax.String(), cyan, green) +
 		strCol("BR Margin, Min [67]", s.BrMin.String(), cyan, green) +
 		strCol("Vendor SN [68-83]", s.VendorSn.String(), cyan, green) +
-		strCol("Date Code [84-91]", s.DateCode.String(), cyan, green)
+		strCol("Date Code [84-91]", s.DateCode.String(), cyan, green) +
+		strCol("Diagnostic Monitoring Type [92]", fmt.Sprintf("0x%02x (%s)", byte(s.DiagMonitType), s.DiagMonitType), cyan, green) +
+		strCol("Enhanced Options [93]", fmt.Sprintf("0x%02x (%s)", byte(s.EnhancedOpts), s.EnhancedOpts), cyan, green) +
+		strCol("SFF-8472 Compliance [94]", fmt.Sprintf("0x%02x (%s)", byte(s.Sff8472Comp), s.Sff8472Comp), cyan, green) +
+		strCol("CC_EXT [95]", ccExt.Status(s.bytes()), cyan, checkCodeColor(ccExt, s.bytes()))
 
 	if s.Vendor.String() == "Arista Networks" && strings.HasPrefix(s.VendorPn.String(), "CAB-Q-S-") {
 		str += strCol("Vendor SA [120]", fmt.Sprintf("%x", s.VendorAristaSa), cyan, green)
@@ -166,7 +328,40 @@ func (s *Sff8079) StringCol() string {
 		strCol("Vcc [A2h 98-99]", s.Vcc.String(), cyan, green) +
 		strCol("TX Bias [A2h 100-101]", s.TxBias.String(), cyan, green) +
 		strCol("TX Power [A2h 102-103]", s.TxPower.String(), cyan, green) +
-		strCol("RX Power [A2h 104-105]", s.RxPower.String(), cyan, green)
+		strCol("RX Power [A2h 104-105]", s.RxPower.String(), cyan, green) +
+		strColRaw("Status/Control [A2h 110]", s.StatusControl.StringCol(), cyan) +
+		strColRaw("Alarm Flags [A2h 112-113]", s.Alarms.StringCol(), cyan) +
+		strColRaw("Warning Flags [A2h 116-117]", s.Warnings.StringCol(), cyan)
+
+	for _, t := range s.thresholds() {
+		str += strCol(t[0], t[1], cyan, green)
+	}
 
 	return str
 }
+
+// thresholds returns the label and value of each A2h alarm and warning threshold
+func (s *Sff8079) thresholds() [][2]string {
+	return [][2]string{
+		{"Temperature High Alarm [A2h 0-1]", s.TempThresholds.HighAlarm.String()},
+		{"Temperature Low Alarm [A2h 2-3]", s.TempThresholds.LowAlarm.String()},
+		{"Temperature High Warning [A2h 4-5]", s.TempThresholds.HighWarning.String()},
+		{"Temperature Low Warning [A2h 6-7]", s.TempThresholds.LowWarning.String()},
+		{"Vcc High Alarm [A2h 8-9]", s.VccThresholds.HighAlarm.String()},
+		{"Vcc Low Alarm [A2h 10-11]", s.VccThresholds.LowAlarm.String()},
+		{"Vcc High Warning [A2h 12-13]", s.VccThresholds.HighWarning.String()},
+		{"Vcc Low Warning [A2h 14-15]", s.VccThresholds.LowWarning.String()},
+		{"TX Bias High Alarm [A2h 16-17]", s.TxBiasThresholds.HighAlarm.String()},
+		{"TX Bias Low Alarm [A2h 18-19]", s.TxBiasThresholds.LowAlarm.String()},
+		{"TX Bias High Warning [A2h 20-21]", s.TxBiasThresholds.HighWarning.String()},
+		{"TX Bias Low Warning [A2h 22-23]", s.TxBiasThresholds.LowWarning.String()},
+		{"TX Power High Alarm [A2h 24-25]", s.TxPowerThresholds.HighAlarm.String()},
+		{"TX Power Low Alarm [A2h 26-27]", s.TxPowerThresholds.LowAlarm.String()},
+		{"TX Power High Warning [A2h 28-29]", s.TxPowerThresholds.HighWarning.String()},
+		{"TX Power Low Warning [A2h 30-31]", s.TxPowerThresholds.LowWarning.String()},
+		{"RX Power High Alarm [A2h 32-33]", s.RxPowerThresholds.HighAlarm.String()},
+		{"RX Power Low Alarm [A2h 34-35]", s.RxPowerThresholds.LowAlarm.String()},
+		{"RX Power High Warning [A2h 36-37]", s.RxPowerThresholds.HighWarning.String()},
+		{"RX Power Low Warning [A2h 38-39]", s.RxPowerThresholds.LowWarning.String()},
+	}
+}
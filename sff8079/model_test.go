@@ -0,0 +1,46 @@
+package sff8079
+
+import (
+	"testing"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+func TestDecodeRegions(t *testing.T) {
+	b := testEeprom(0x68)
+	b[20] = 'A'
+	b[256+96] = 0x19 // Temperature 25 C
+
+	s, err := Decode(b)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if len(s.Regions()) != 4 || s.Diagnostics() == nil {
+		t.Errorf("Regions() = %v, want A0h and A2h with diagnostics", s.Regions())
+	}
+
+	// Reusing the buffer must not change the decoded module
+	b[20], b[256+96] = 'B', 0x50
+	if s.Vendor[0] != 'A' || s.Temperature.Celsius() != 25 {
+		t.Errorf("Decoded module aliases the input buffer")
+	}
+
+	// A0h only
+	s, err = Decode(b[:256])
+	if err != nil {
+		t.Fatalf("Decode of A0h failed: %v", err)
+	}
+	want := []common.Region{common.LowerPage(common.AddressA0), common.UpperPage(common.AddressA0, 0, 0)}
+	if r := s.Regions(); len(r) != 2 || r[0] != want[0] || r[1] != want[1] {
+		t.Errorf("Regions() = %v, want %v", r, want)
+	}
+	if d := s.Diagnostics(); d != nil {
+		t.Errorf("Diagnostics() = %+v without A2h, want nil", d)
+	}
+
+	for _, n := range []int{128, 300} {
+		if _, err := Decode(b[:n]); err == nil {
+			t.Errorf("Expected error for %d byte dump", n)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package sff8079
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"strings"
 )
 
@@ -132,16 +134,34 @@ func (o *Options) String() string {
 	return strings.Join(parts, ", ")
 }
 
-// MarshalJSON implements custom JSON marshaling
-func (o *Options) MarshalJSON() ([]byte, error) {
-	type Alias Options
-	return json.Marshal(&struct {
-		*Alias
-		PowerLevel string `json:"powerLevel"`
-		Summary    string `json:"summary"`
-	}{
-		Alias:      (*Alias)(o),
-		PowerLevel: o.GetPowerLevel(),
-		Summary:    o.String(),
-	})
+func (o Options) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"powerLevel": o.GetPowerLevel(),
+		"summary":    o.String(),
+		"hex":        hex.EncodeToString(o[:]),
+	}
+	return json.Marshal(m)
+}
+
+func (o *Options) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	s, ok := m["hex"].(string)
+	if !ok {
+		return fmt.Errorf("missing hex value for Options type")
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	if len(b) != len(o) {
+		return fmt.Errorf("Options needs %d bytes, got %d", len(o), len(b))
+	}
+
+	copy(o[:], b)
+	return nil
 }
@@ -0,0 +1,194 @@
+package sff8079
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// StatusControl represents the optional status/control bits (A2h byte 110)
+// according to SFF-8472 Table 9-11
+type StatusControl byte
+
+// Accessor methods for A2h byte 110
+func (s StatusControl) IsTxDisableState() bool { return s&0x80 != 0 } // Bit 7 - TX_DISABLE pin state
+func (s StatusControl) IsSoftTxDisable() bool  { return s&0x40 != 0 } // Bit 6 - Soft TX Disable Select
+func (s StatusControl) IsRs1State() bool       { return s&0x20 != 0 } // Bit 5 - RS(1) pin state
+func (s StatusControl) IsRs0State() bool       { return s&0x10 != 0 } // Bit 4 - Rate_Select/RS(0) pin state
+func (s StatusControl) IsSoftRateSelect() bool { return s&0x08 != 0 } // Bit 3 - Soft Rate_Select Select
+func (s StatusControl) IsTxFault() bool        { return s&0x04 != 0 } // Bit 2 - TX_FAULT state
+func (s StatusControl) IsRxLos() bool          { return s&0x02 != 0 } // Bit 1 - Rx_LOS state
+func (s StatusControl) IsDataNotReady() bool   { return s&0x01 != 0 } // Bit 0 - Data_Ready_Bar
+
+// List returns the names of the set status/control bits
+func (s StatusControl) List() []string {
+	l := []string{}
+	if s.IsTxDisableState() {
+		l = append(l, "TX Disable")
+	}
+	if s.IsSoftTxDisable() {
+		l = append(l, "Soft TX Disable")
+	}
+	if s.IsRs1State() {
+		l = append(l, "RS(1)")
+	}
+	if s.IsRs0State() {
+		l = append(l, "RS(0)")
+	}
+	if s.IsSoftRateSelect() {
+		l = append(l, "Soft Rate Select")
+	}
+	if s.IsTxFault() {
+		l = append(l, "TX Fault")
+	}
+	if s.IsRxLos() {
+		l = append(l, "RX LOS")
+	}
+	if s.IsDataNotReady() {
+		l = append(l, "Data Not Ready")
+	}
+	return l
+}
+
+func (s StatusControl) String() string {
+	l := s.List()
+	if len(l) == 0 {
+		return "None"
+	}
+	return strings.Join(l, ", ")
+}
+
+// StringCol returns String with TX fault and LOS highlighted
+func (s StatusControl) StringCol() string {
+	if s.IsTxFault() || s.IsRxLos() {
+		return red + s.String() + clear
+	}
+	return green + s.String() + clear
+}
+
+func (s StatusControl) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": s.List(),
+		"hex":   hex.EncodeToString([]byte{byte(s)}),
+	}
+	return json.Marshal(m)
+}
+
+func (s *StatusControl) UnmarshalJSON(in []byte) error {
+	var b [1]byte
+	if err := unmarshalHex(in, b[:], "StatusControl"); err != nil {
+		return err
+	}
+	*s = StatusControl(b[0])
+	return nil
+}
+
+// DiagFlags represents the alarm flags (A2h bytes 112-113) or the warning
+// flags (A2h bytes 116-117) according to SFF-8472 Table 9-12
+type DiagFlags [2]byte
+
+// Accessor methods for A2h byte 112/116
+func (f DiagFlags) IsTempHigh() bool    { return f[0]&0x80 != 0 } // Bit 7
+func (f DiagFlags) IsTempLow() bool     { return f[0]&0x40 != 0 } // Bit 6
+func (f DiagFlags) IsVccHigh() bool     { return f[0]&0x20 != 0 } // Bit 5
+func (f DiagFlags) IsVccLow() bool      { return f[0]&0x10 != 0 } // Bit 4
+func (f DiagFlags) IsTxBiasHigh() bool  { return f[0]&0x08 != 0 } // Bit 3
+func (f DiagFlags) IsTxBiasLow() bool   { return f[0]&0x04 != 0 } // Bit 2
+func (f DiagFlags) IsTxPowerHigh() bool { return f[0]&0x02 != 0 } // Bit 1
+func (f DiagFlags) IsTxPowerLow() bool  { return f[0]&0x01 != 0 } // Bit 0
+
+// Accessor methods for A2h byte 113/117
+func (f DiagFlags) IsRxPowerHigh() bool    { return f[1]&0x80 != 0 } // Bit 7
+func (f DiagFlags) IsRxPowerLow() bool     { return f[1]&0x40 != 0 } // Bit 6
+func (f DiagFlags) IsLaserTempHigh() bool  { return f[1]&0x20 != 0 } // Bit 5
+func (f DiagFlags) IsLaserTempLow() bool   { return f[1]&0x10 != 0 } // Bit 4
+func (f DiagFlags) IsTecCurrentHigh() bool { return f[1]&0x08 != 0 } // Bit 3
+func (f DiagFlags) IsTecCurrentLow() bool  { return f[1]&0x04 != 0 } // Bit 2
+
+// Any returns true if any flag is set
+func (f DiagFlags) Any() bool {
+	return f[0] != 0 || f[1]&0xfc != 0
+}
+
+// List returns the names of the set flags
+func (f DiagFlags) List() []string {
+	l := []string{}
+	for _, x := range []struct {
+		set  bool
+		name string
+	}{
+		{f.IsTempHigh(), "Temperature High"},
+		{f.IsTempLow(), "Temperature Low"},
+		{f.IsVccHigh(), "Vcc High"},
+		{f.IsVccLow(), "Vcc Low"},
+		{f.IsTxBiasHigh(), "TX Bias High"},
+		{f.IsTxBiasLow(), "TX Bias Low"},
+		{f.IsTxPowerHigh(), "TX Power High"},
+		{f.IsTxPowerLow(), "TX Power Low"},
+		{f.IsRxPowerHigh(), "RX Power High"},
+		{f.IsRxPowerLow(), "RX Power Low"},
+		{f.IsLaserTempHigh(), "Laser Temperature High"},
+		{f.IsLaserTempLow(), "Laser Temperature Low"},
+		{f.IsTecCurrentHigh(), "TEC Current High"},
+		{f.IsTecCurrentLow(), "TEC Current Low"},
+	} {
+		if x.set {
+			l = append(l, x.name)
+		}
+	}
+	return l
+}
+
+func (f DiagFlags) String() string {
+	l := f.List()
+	if len(l) == 0 {
+		return "None"
+	}
+	return strings.Join(l, ", ")
+}
+
+// StringCol returns String with set flags highlighted
+func (f DiagFlags) StringCol() string {
+	if f.Any() {
+		return red + f.String() + clear
+	}
+	return green + f.String() + clear
+}
+
+func (f DiagFlags) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": f.List(),
+		"hex":   hex.EncodeToString(f[:]),
+	}
+	return json.Marshal(m)
+}
+
+func (f *DiagFlags) UnmarshalJSON(in []byte) error {
+	return unmarshalHex(in, f[:], "DiagFlags")
+}
+
+// unmarshalHex decodes the "hex" member of a JSON object into b
+func unmarshalHex(in []byte, b []byte, name string) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	s, ok := m["hex"].(string)
+	if !ok {
+		return fmt.Errorf("missing hex value for %s type", name)
+	}
+	h, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+
+	if len(h) < len(b) {
+		return fmt.Errorf("length is shorter then %s type", name)
+	}
+
+	copy(b, h)
+	return nil
+}
@@ -0,0 +1,63 @@
+package sff8079
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestStatusControl(t *testing.T) {
+	s := StatusControl(0x46)
+	if !s.IsSoftTxDisable() || !s.IsTxFault() || !s.IsRxLos() {
+		t.Errorf("Expected soft TX disable, TX fault and RX LOS for 0x%02x", byte(s))
+	}
+	if s.IsDataNotReady() {
+		t.Error("IsDataNotReady() should be false for 0x46")
+	}
+	if got, want := s.String(), "Soft TX Disable, TX Fault, RX LOS"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := StatusControl(0).String(); got != "None" {
+		t.Errorf("String() = %q, want None", got)
+	}
+}
+
+func TestDiagFlags(t *testing.T) {
+	f := DiagFlags{0x81, 0x43}
+	want := []string{"Temperature High", "TX Power Low", "RX Power Low"}
+	if got := f.List(); !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+	if !f.Any() {
+		t.Error("Any() should be true")
+	}
+	// Reserved bits alone are not flags
+	if (DiagFlags{0x00, 0x03}).Any() {
+		t.Error("Any() should ignore reserved bits")
+	}
+
+	b, err := json.Marshal(f)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var got DiagFlags
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got != f {
+		t.Errorf("Round trip gave %v, want %v", got, f)
+	}
+}
+
+func TestStatusUnmarshalJSONInvalid(t *testing.T) {
+	for _, in := range []string{`{}`, `{"hex": 1}`, `{"hex": ""}`, `{"hex": "zz"}`} {
+		var s StatusControl
+		if err := json.Unmarshal([]byte(in), &s); err == nil {
+			t.Errorf("StatusControl: expected error for %s", in)
+		}
+		var f DiagFlags
+		if err := json.Unmarshal([]byte(in), &f); err == nil {
+			t.Errorf("DiagFlags: expected error for %s", in)
+		}
+	}
+}
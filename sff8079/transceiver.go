@@ -1,12 +1,12 @@
 package sff8079
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
-	"unsafe"
 )
 
 const (
@@ -155,8 +155,10 @@ func (t Transceiver) List() []string {
 	return r
 }
 
+// Uint64 returns the transceiver codes with byte 0 as the least significant
+// byte, matching the keys of transceiverNames
 func (t Transceiver) Uint64() uint64 {
-	return *(*uint64)(unsafe.Pointer(&t[0]))
+	return binary.LittleEndian.Uint64(t[:])
 }
 
 func (t Transceiver) String() string {
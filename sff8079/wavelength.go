@@ -0,0 +1,67 @@
+package sff8079
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// LaserWavelength represents bytes 60-61. For optical modules it holds the
+// nominal laser wavelength in nm. For passive and active copper cables (byte 8
+// bits 2 and 3) byte 60 holds the cable specification compliance instead, see
+// PassiveCompliance and ActiveCompliance.
+type LaserWavelength [2]byte
+
+// Nanometers returns the laser wavelength in nm
+func (w LaserWavelength) Nanometers() uint16 {
+	return uint16(w[0])<<8 | uint16(w[1])
+}
+
+// PassiveCompliance returns the passive cable specification compliance
+// according to SFF-8472 Table 8-1
+func (w LaserWavelength) PassiveCompliance() []string {
+	var l []string
+	if w[0]&0x01 != 0 {
+		l = append(l, "SFF-8431 Appendix E")
+	}
+	if w[0]&0x02 != 0 {
+		l = append(l, "FC-PI-4 Annex H")
+	}
+	return l
+}
+
+// ActiveCompliance returns the active cable specification compliance
+// according to SFF-8472 Table 8-2
+func (w LaserWavelength) ActiveCompliance() []string {
+	var l []string
+	if w[0]&0x01 != 0 {
+		l = append(l, "SFF-8431 Appendix E")
+	}
+	if w[0]&0x02 != 0 {
+		l = append(l, "FC-PI-4 Annex H")
+	}
+	if w[0]&0x04 != 0 {
+		l = append(l, "SFF-8431 Limiting")
+	}
+	if w[0]&0x08 != 0 {
+		l = append(l, "FC-PI-4 Limiting")
+	}
+	return l
+}
+
+func (w LaserWavelength) String() string {
+	return fmt.Sprintf("%d nm", w.Nanometers())
+}
+
+func (w LaserWavelength) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": w.Nanometers(),
+		"unit":  "nm",
+		"hex":   hex.EncodeToString(w[:]),
+	}
+	return json.Marshal(m)
+}
+
+func (w *LaserWavelength) UnmarshalJSON(in []byte) error {
+	return unmarshalHex(in, w[:], "LaserWavelength")
+}
@@ -0,0 +1,207 @@
+package sff8636
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// TxDisable holds the software Tx disable bits (Byte 86). Bits 3-0 disable
+// Tx4-Tx1.
+type TxDisable byte
+
+// IsDisabled returns true if the transmitter of channel (1-4) is disabled
+func (t TxDisable) IsDisabled(channel int) bool {
+	return t>>(channel-1)&1 != 0
+}
+
+// List returns the disabled transmitters
+func (t TxDisable) List() []string {
+	var l []string
+	for ch := 1; ch <= 4; ch++ {
+		if t.IsDisabled(ch) {
+			l = append(l, fmt.Sprintf("Tx%d", ch))
+		}
+	}
+	return l
+}
+
+func (t TxDisable) String() string {
+	return listOrNone(t.List())
+}
+
+func (t TxDisable) MarshalJSON() ([]byte, error) {
+	return marshalFlags(t.List(), []byte{byte(t)})
+}
+
+func (t *TxDisable) UnmarshalJSON(in []byte) error {
+	var b [1]byte
+	if err := unmarshalHex(in, b[:], "TxDisable"); err != nil {
+		return err
+	}
+	*t = TxDisable(b[0])
+	return nil
+}
+
+// RateSelect holds the 2-bit software rate select of each channel (Bytes
+// 87-88), channel 1 in bits 1-0 and channel 4 in bits 7-6. The meaning of the
+// values depends on the Extended Rate Select Compliance (Byte 141).
+type RateSelect byte
+
+// Channel returns the rate select value of channel (1-4)
+func (r RateSelect) Channel(channel int) byte {
+	return byte(r>>(2*(channel-1))) & 0x03
+}
+
+func (r RateSelect) String() string {
+	l := make([]string, 4)
+	for ch := 1; ch <= 4; ch++ {
+		l[ch-1] = fmt.Sprintf("Ch%d %02b", ch, r.Channel(ch))
+	}
+	return strings.Join(l, ", ")
+}
+
+func (r RateSelect) MarshalJSON() ([]byte, error) {
+	v := map[string]interface{}{
+		"value": []int{int(r.Channel(1)), int(r.Channel(2)), int(r.Channel(3)), int(r.Channel(4))},
+		"hex":   hex.EncodeToString([]byte{byte(r)}),
+	}
+	return json.Marshal(v)
+}
+
+func (r *RateSelect) UnmarshalJSON(in []byte) error {
+	var b [1]byte
+	if err := unmarshalHex(in, b[:], "RateSelect"); err != nil {
+		return err
+	}
+	*r = RateSelect(b[0])
+	return nil
+}
+
+// ApplicationSelect holds the software application select codes of the Rx
+// (Bytes 89-92) or Tx (Bytes 94-97) channels, stored in the order channel 4
+// to channel 1
+type ApplicationSelect [4]byte
+
+// Channel returns the application select code of channel (1-4)
+func (a ApplicationSelect) Channel(channel int) byte {
+	return a[4-channel]
+}
+
+func (a ApplicationSelect) String() string {
+	l := make([]string, 4)
+	for ch := 1; ch <= 4; ch++ {
+		l[ch-1] = fmt.Sprintf("Ch%d 0x%02x", ch, a.Channel(ch))
+	}
+	return strings.Join(l, ", ")
+}
+
+func (a ApplicationSelect) MarshalJSON() ([]byte, error) {
+	v := map[string]interface{}{
+		"value": []int{int(a.Channel(1)), int(a.Channel(2)), int(a.Channel(3)), int(a.Channel(4))},
+		"hex":   hex.EncodeToString(a[:]),
+	}
+	return json.Marshal(v)
+}
+
+func (a *ApplicationSelect) UnmarshalJSON(in []byte) error {
+	return unmarshalHex(in, a[:], "ApplicationSelect")
+}
+
+// CdrControl holds the CDR on/off control bits (Byte 98). Bits 7-4 enable the
+// Tx4-Tx1 CDRs and bits 3-0 the Rx4-Rx1 CDRs.
+type CdrControl byte
+
+// IsRxEnabled returns true if the Rx CDR of channel (1-4) is on
+func (c CdrControl) IsRxEnabled(channel int) bool {
+	return c>>(channel-1)&1 != 0
+}
+
+// IsTxEnabled returns true if the Tx CDR of channel (1-4) is on
+func (c CdrControl) IsTxEnabled(channel int) bool {
+	return c>>(channel+3)&1 != 0
+}
+
+// List returns the CDRs that are on
+func (c CdrControl) List() []string {
+	var l []string
+	for ch := 1; ch <= 4; ch++ {
+		if c.IsRxEnabled(ch) {
+			l = append(l, fmt.Sprintf("Rx%d", ch))
+		}
+	}
+	for ch := 1; ch <= 4; ch++ {
+		if c.IsTxEnabled(ch) {
+			l = append(l, fmt.Sprintf("Tx%d", ch))
+		}
+	}
+	return l
+}
+
+func (c CdrControl) String() string {
+	return listOrNone(c.List())
+}
+
+func (c CdrControl) MarshalJSON() ([]byte, error) {
+	return marshalFlags(c.List(), []byte{byte(c)})
+}
+
+func (c *CdrControl) UnmarshalJSON(in []byte) error {
+	var b [1]byte
+	if err := unmarshalHex(in, b[:], "CdrControl"); err != nil {
+		return err
+	}
+	*c = CdrControl(b[0])
+	return nil
+}
+
+// SignalControl configures the function of the LPMode/TxDis and IntL/LOSL
+// pins (Byte 99), if supported according to Options (Byte 193)
+type SignalControl byte
+
+const (
+	// Bit 1: IntL/LOSL output signal control
+	SignalControlLosL SignalControl = 0x02
+	// Bit 0: LPMode/TxDis input signal control
+	SignalControlTxDis SignalControl = 0x01
+)
+
+// IsLosL returns true if the IntL/LOSL pin is configured as Rx LOSL
+func (s SignalControl) IsLosL() bool {
+	return s&SignalControlLosL != 0
+}
+
+// IsTxDis returns true if the LPMode/TxDis pin is configured as TxDis
+func (s SignalControl) IsTxDis() bool {
+	return s&SignalControlTxDis != 0
+}
+
+func (s SignalControl) String() string {
+	intL, lpMode := "IntL", "LPMode"
+	if s.IsLosL() {
+		intL = "LOSL"
+	}
+	if s.IsTxDis() {
+		lpMode = "TxDis"
+	}
+	return fmt.Sprintf("IntL/LOSL pin as %s, LPMode/TxDis pin as %s", intL, lpMode)
+}
+
+func (s SignalControl) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"losL":  s.IsLosL(),
+		"txDis": s.IsTxDis(),
+		"hex":   hex.EncodeToString([]byte{byte(s)}),
+	}
+	return json.Marshal(m)
+}
+
+func (s *SignalControl) UnmarshalJSON(in []byte) error {
+	var b [1]byte
+	if err := unmarshalHex(in, b[:], "SignalControl"); err != nil {
+		return err
+	}
+	*s = SignalControl(b[0])
+	return nil
+}
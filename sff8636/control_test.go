@@ -0,0 +1,96 @@
+package sff8636
+
+import (
+	"encoding/json"
+	"testing"
+	"unsafe"
+)
+
+func TestControlLayout(t *testing.T) {
+	offsets := []struct {
+		name string
+		got  uintptr
+		want uintptr
+	}{
+		{"TxDisable", unsafe.Offsetof(Sff8636{}.TxDisable), 86},
+		{"RxRateSelect", unsafe.Offsetof(Sff8636{}.RxRateSelect), 87},
+		{"RxAppSelect", unsafe.Offsetof(Sff8636{}.RxAppSelect), 89},
+		{"ControlStatus", unsafe.Offsetof(Sff8636{}.ControlStatus), 93},
+		{"TxAppSelect", unsafe.Offsetof(Sff8636{}.TxAppSelect), 94},
+		{"CdrControl", unsafe.Offsetof(Sff8636{}.CdrControl), 98},
+		{"SignalControl", unsafe.Offsetof(Sff8636{}.SignalControl), 99},
+		{"IdentifierPage01", unsafe.Offsetof(Sff8636{}.IdentifierPage01), 128},
+	}
+	for _, o := range offsets {
+		if o.got != o.want {
+			t.Errorf("%s at offset %d, want %d", o.name, o.got, o.want)
+		}
+	}
+}
+
+func TestTxDisable(t *testing.T) {
+	d := TxDisable(0x05)
+	if !d.IsDisabled(1) || d.IsDisabled(2) || !d.IsDisabled(3) || d.IsDisabled(4) {
+		t.Errorf("Unexpected Tx disable decoding for 0x%02x", byte(d))
+	}
+	if s := d.String(); s != "Tx1, Tx3" {
+		t.Errorf("TxDisable.String() = %q", s)
+	}
+	if s := TxDisable(0xF0).String(); s != "None" {
+		t.Errorf("TxDisable(0xf0).String() = %q, want None", s)
+	}
+}
+
+func TestRateSelect(t *testing.T) {
+	r := RateSelect(0xE4) // Ch4 11, Ch3 10, Ch2 01, Ch1 00
+	for ch := 1; ch <= 4; ch++ {
+		if v := r.Channel(ch); v != byte(ch-1) {
+			t.Errorf("Channel(%d) = %d, want %d", ch, v, ch-1)
+		}
+	}
+	if s := r.String(); s != "Ch1 00, Ch2 01, Ch3 10, Ch4 11" {
+		t.Errorf("RateSelect.String() = %q", s)
+	}
+}
+
+func TestApplicationSelect(t *testing.T) {
+	a := ApplicationSelect{0x04, 0x03, 0x02, 0x01}
+	for ch := 1; ch <= 4; ch++ {
+		if v := a.Channel(ch); v != byte(ch) {
+			t.Errorf("Channel(%d) = %d, want %d", ch, v, ch)
+		}
+	}
+
+	b, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(b) != `{"hex":"04030201","value":[1,2,3,4]}` {
+		t.Errorf("Marshal = %s", b)
+	}
+	var got ApplicationSelect
+	if err := json.Unmarshal(b, &got); err != nil || got != a {
+		t.Errorf("JSON round trip = % x, %v, want % x", got, err, a)
+	}
+}
+
+func TestCdrControl(t *testing.T) {
+	c := CdrControl(0x81)
+	if !c.IsRxEnabled(1) || c.IsRxEnabled(2) || !c.IsTxEnabled(4) || c.IsTxEnabled(1) {
+		t.Errorf("Unexpected CDR control decoding for 0x%02x", byte(c))
+	}
+	if s := c.String(); s != "Rx1, Tx4" {
+		t.Errorf("CdrControl.String() = %q", s)
+	}
+}
+
+func TestSignalControl(t *testing.T) {
+	if s := SignalControl(0x03).String(); s != "IntL/LOSL pin as LOSL, LPMode/TxDis pin as TxDis" {
+		t.Errorf("SignalControl.String() = %q", s)
+	}
+
+	var c ControlStatus
+	if err := json.Unmarshal([]byte(`{"hex":"02"}`), &c); err != nil || !c.IsLowPowerMode() {
+		t.Errorf("ControlStatus from JSON = 0x%02x, %v", byte(c), err)
+	}
+}
@@ -116,3 +116,12 @@ func (d DeviceTechnology) MarshalJSON() ([]byte, error) {
 	}
 	return json.Marshal(m)
 }
+
+func (d *DeviceTechnology) UnmarshalJSON(in []byte) error {
+	var b [1]byte
+	if err := unmarshalHex(in, b[:], "DeviceTechnology"); err != nil {
+		return err
+	}
+	*d = DeviceTechnology(b[0])
+	return nil
+}
@@ -0,0 +1,50 @@
+package sff8636
+
+import "github.com/bluecmd/go-sff/common"
+
+// Diagnostics returns the module monitors and the four channels of
+// ChannelMonitoring. The alarm states are derived from the page 03h
+// thresholds if present, and from the latched interrupt flags (Bytes 6-14)
+// otherwise. Monitors whose page 03h thresholds are all zero are reported as
+// common.AlarmStateNone. Returns nil if the Diagnostic Monitoring Type (Byte
+// 220) advertises no monitors, as on passive copper cables.
+func (s *Sff8636) Diagnostics() *common.Diagnostics {
+	if byte(s.DiagMonType)&0x3c == 0 {
+		return nil
+	}
+	c := &s.ChannelMonitoring
+	rx := []common.PowerMilliWattBE{c.Rx1Power, c.Rx2Power, c.Rx3Power, c.Rx4Power}
+	bias := []common.CurrentMilliAmpBE{c.Tx1Bias, c.Tx2Bias, c.Tx3Bias, c.Tx4Bias}
+	tx := []common.PowerMilliWattBE{c.Tx1Power, c.Tx2Power, c.Tx3Power, c.Tx4Power}
+
+	d := &common.Diagnostics{
+		Temperature:      s.Temperature,
+		Vcc:              s.SupplyVoltage,
+		TemperatureState: s.ModuleFlags.TemperatureState(),
+		VccState:         s.ModuleFlags.VccState(),
+		Lanes:            make([]common.LaneDiagnostics, 4),
+	}
+	for i := range d.Lanes {
+		ch := i + 1
+		d.Lanes[i] = common.LaneDiagnostics{
+			Lane:         ch,
+			TxBias:       bias[i],
+			TxPower:      tx[i],
+			RxPower:      rx[i],
+			TxBiasState:  s.ChannelFlags.TxBias(ch).State(),
+			TxPowerState: s.ChannelFlags.TxPower(ch).State(),
+			RxPowerState: s.ChannelFlags.RxPower(ch).State(),
+		}
+	}
+
+	if p := s.Page03; p != nil {
+		d.TemperatureState = p.TempThresholds.State(s.Temperature)
+		d.VccState = p.VccThresholds.State(s.SupplyVoltage)
+		for i, st := range s.ChannelStates() {
+			d.Lanes[i].TxBiasState = st.TxBias
+			d.Lanes[i].TxPowerState = st.TxPower
+			d.Lanes[i].RxPowerState = st.RxPower
+		}
+	}
+	return d
+}
@@ -0,0 +1,66 @@
+package sff8636
+
+import (
+	"testing"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+func TestDiagnosticsThresholds(t *testing.T) {
+	b := make([]byte, 640)
+	b[128] = 0x11
+	b[220] = 0x3c             // Temperature, Vcc, average Rx and Tx power
+	b[6] = 0x80               // Latched temperature high alarm
+	b[9] = 0x40               // Latched Rx1 power low alarm
+	b[36], b[37] = 0x03, 0xe8 // Rx2 power 0.1 mW
+	p3 := b[512:]
+	copy(p3[0:8], []byte{0x50, 0, 0xf6, 0, 0x4b, 0, 0xfb, 0})               // 80/-10/75/-5 C
+	copy(p3[48:56], []byte{0x27, 0x10, 0x00, 0x0a, 0x13, 0x88, 0x07, 0xd0}) // Rx power 1/0.001/0.5/0.2 mW
+
+	s, err := Decode(b)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	// Without page 03h the latched flags are used
+	p := s.Page03
+	s.Page03 = nil
+	d := s.Diagnostics()
+	if d.TemperatureState != common.AlarmStateHighAlarm || d.Lanes[0].RxPowerState != common.AlarmStateLowAlarm {
+		t.Errorf("Flag states = %s, %s, want high alarm, low alarm", d.TemperatureState, d.Lanes[0].RxPowerState)
+	}
+
+	// With page 03h the values are compared to the thresholds
+	s.Page03 = p
+	d = s.Diagnostics()
+	if d.TemperatureState != common.AlarmStateNormal {
+		t.Errorf("TemperatureState = %s, want Normal", d.TemperatureState)
+	}
+	if d.Lanes[1].RxPowerState != common.AlarmStateLowWarning || d.Lanes[1].RxPower.MilliWatt() != 0.1 {
+		t.Errorf("Lane 2 = %+v, want 0.1 mW low warning", d.Lanes[1])
+	}
+}
+
+func TestDiagnosticsNotImplemented(t *testing.T) {
+	b := make([]byte, 640)
+	b[128] = 0x11
+	copy(b[512+48:], []byte{0x27, 0x10, 0x00, 0x0a, 0x13, 0x88, 0x07, 0xd0})
+
+	s, err := Decode(b)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if d := s.Diagnostics(); d != nil {
+		t.Errorf("Diagnostics() = %+v without monitors, want nil", d)
+	}
+
+	// Monitors without thresholds are not compared
+	s.DiagMonType = 0x3c
+	d := s.Diagnostics()
+	if d.TemperatureState != common.AlarmStateNone || d.Lanes[0].TxBiasState != common.AlarmStateNone {
+		t.Errorf("States = %s, %s, want none", d.TemperatureState, d.Lanes[0].TxBiasState)
+	}
+	if d.Lanes[0].RxPowerState != common.AlarmStateLowAlarm {
+		t.Errorf("Rx power state = %s, want low alarm", d.Lanes[0].RxPowerState)
+	}
+}
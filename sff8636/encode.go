@@ -0,0 +1,60 @@
+package sff8636
+
+import "github.com/bluecmd/go-sff/common"
+
+// flatPages lists the upper pages following upper page 00h in the flat layout
+var flatPages = []uint8{0x01, 0x02, 0x03}
+
+// flatImage returns the flat dump of m up to the last upper page present
+func flatImage(m *common.Memory) []byte {
+	n := len(flatPages)
+	for n > 0 && !m.Has(common.UpperPage(common.AddressA0, 0, flatPages[n-1])) {
+		n--
+	}
+	return m.FlatSff8636()[:(2+n)*common.PageLen]
+}
+
+// Encode returns the flat dump of s, the lower page followed by upper pages
+// 00h-03h. Bytes not mapped by Sff8636, such as pages 01h and 02h, are taken
+// from the dump s was decoded from, so decoding and encoding reproduces the
+// dump exactly. Values that were not decoded from a dump encode as 256 bytes,
+// or 640 bytes with the unmapped pages zero if Page03 is set.
+//
+// With updateChecksums CC_BASE and CC_EXT are recomputed in the result, s is
+// not modified.
+func (s *Sff8636) Encode(updateChecksums bool) ([]byte, error) {
+	c := *s
+	if updateChecksums {
+		c.UpdateChecksums()
+	}
+
+	n := len(s.image)
+	if n == 0 {
+		n = mappedLen
+	}
+	if s.Page03 != nil {
+		n = 5 * common.PageLen
+	}
+	b := make([]byte, n)
+	copy(b, s.image)
+	copy(b, c.bytes())
+	if s.Page03 != nil {
+		copy(b[4*common.PageLen:], s.Page03.bytes())
+	}
+	return b, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, see Encode
+func (s *Sff8636) MarshalBinary() ([]byte, error) {
+	return s.Encode(false)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, see Decode
+func (s *Sff8636) UnmarshalBinary(b []byte) error {
+	d, err := Decode(b)
+	if err != nil {
+		return err
+	}
+	*s = *d
+	return nil
+}
@@ -0,0 +1,286 @@
+package sff8636
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+var ethtoolRevisionNames = map[RevisionCompliance]string{
+	RevNotSpecified:     "Revision not specified",
+	RevSff8436Rev48:     "SFF-8436 Rev 4.8 or earlier",
+	RevSff8436Rev48Plus: "SFF-8436 Rev 4.8 or earlier",
+	RevSff8636Rev13:     "SFF-8636 Rev 1.3 or earlier",
+	RevSff8636Rev14:     "SFF-8636 Rev 1.4",
+	RevSff8636Rev15:     "SFF-8636 Rev 1.5",
+	RevSff8636Rev20:     "SFF-8636 Rev 2.0",
+	RevSff8636Rev25:     "SFF-8636 Rev 2.5/2.6/2.7",
+	RevSff8636Rev28:     "SFF-8636 Rev 2.8/2.9/2.10",
+}
+
+var ethtoolPowerClassNames = map[byte]string{
+	PwrClass1: "1.5W max. Power consumption",
+	PwrClass2: "2.0W max. Power consumption",
+	PwrClass3: "2.5W max. Power consumption",
+	PwrClass4: "3.5W max. Power consumption",
+}
+
+var ethtoolExtPowerClassNames = map[byte]string{
+	ExtPwrClass5: "4.0W max. Power consumption,",
+	ExtPwrClass6: "4.5W max. Power consumption,",
+	ExtPwrClass7: "5.0W max. Power consumption,",
+}
+
+// ethtoolExtComplianceNames are ethtool's transceiver types of the SFF-8024
+// extended compliance codes (byte 192), from qsfp.c. Other codes are
+// "(reserved or unknown)". The codes 50h-55h are from an SFF-8024 revision
+// that has since reassigned them.
+var ethtoolExtComplianceNames = map[LinkCodes]string{
+	common.ExtComplianceUnspecified:   "(reserved or unknown)",
+	common.ExtCompliance100gAoc5e5:    "100G Ethernet: 100G AOC or 25GAUI C2M AOC with worst BER of 5x10^(-5)",
+	common.ExtCompliance100gBaseSr4:   "100G Ethernet: 100G Base-SR4 or 25GBase-SR",
+	common.ExtCompliance100gBaseLr4:   "100G Ethernet: 100G Base-LR4",
+	common.ExtCompliance100gBaseEr4:   "100G Ethernet: 100G Base-ER4",
+	common.ExtCompliance100gBaseSr10:  "100G Ethernet: 100G Base-SR10",
+	common.ExtCompliance100gCwdm4:     "100G Ethernet: 100G CWDM4 MSA with FEC",
+	common.ExtCompliance100gPsm4:      "100G Ethernet: 100G PSM4 Parallel SMF",
+	common.ExtCompliance100gAcc5e5:    "100G Ethernet: 100G ACC or 25GAUI C2M ACC with worst BER of 5x10^(-5)",
+	common.ExtComplianceObsolete09:    "100G Ethernet: 100G CWDM4 MSA without FEC",
+	common.ExtCompliance100gBaseCr4:   "100G Ethernet: 100G Base-CR4 or 25G Base-CR CA-L",
+	common.ExtCompliance25gBaseCrS:    "25G Ethernet: 25G Base-CR CA-S",
+	common.ExtCompliance25gBaseCrN:    "25G Ethernet: 25G Base-CR CA-N",
+	common.ExtCompliance40gBaseEr4:    "40G Ethernet: 40G Base-ER4",
+	common.ExtCompliance4x10gBaseSr:   "4x10G Ethernet: 10G Base-SR",
+	common.ExtCompliance40gPsm4:       "40G Ethernet: 40G PSM4 Parallel SMF",
+	common.ExtComplianceG959P1i12d1:   "Ethernet: G959.1 profile P1I1-2D1 (10709 MBd, 2km, 1310nm SM)",
+	common.ExtComplianceG959P1s12d2:   "Ethernet: G959.1 profile P1S1-2D2 (10709 MBd, 40km, 1550nm SM)",
+	common.ExtComplianceG959P1l12d2:   "Ethernet: G959.1 profile P1L1-2D2 (10709 MBd, 80km, 1550nm SM)",
+	common.ExtCompliance10gBaseTSfi:   "10G Ethernet: 10G Base-T with SFI electrical interface",
+	common.ExtCompliance100gClr4:      "100G Ethernet: 100G CLR4",
+	common.ExtCompliance100gAoc1e12:   "100G Ethernet: 100G AOC or 25GAUI C2M AOC with worst BER of 10^(-12)",
+	common.ExtCompliance100gAcc1e12:   "100G Ethernet: 100G ACC or 25GAUI C2M ACC with worst BER of 10^(-12)",
+	common.ExtCompliance100geDwdm2:    "100G Ethernet: 100GE-DWDM2 (DWDM transceiver using 2 wavelengths on a 1550 nm DWDM grid with a reach up to 80 km)",
+	common.ExtCompliance100gWdm1550:   "100G Ethernet: 100G 1550nm WDM (4 wavelengths)",
+	common.ExtCompliance10gBaseTSr:    "10G Ethernet: 10GBASE-T Short Reach (30 meters)",
+	common.ExtCompliance5gBaseT:       "5G Ethernet: 5GBASE-T",
+	common.ExtCompliance2500BaseT:     "2.5G Ethernet: 2.5GBASE-T",
+	common.ExtCompliance40gSwdm4:      "40G Ethernet: 40G SWDM4",
+	common.ExtCompliance100gSwdm4:     "100G Ethernet: 100G SWDM4",
+	common.ExtCompliance100gPam4Bidi:  "100G Ethernet: 100G PAM4 BiDi",
+	common.ExtCompliance4wdm10:        "100G Ethernet: 4WDM-10 MSA (10km version of 100G CWDM4 with same RS(528,514) FEC in host system)",
+	common.ExtCompliance4wdm20:        "100G Ethernet: 4WDM-20 MSA (20km version of 100GBASE-LR4 with RS(528,514) FEC in host system)",
+	common.ExtCompliance4wdm40:        "100G Ethernet: 4WDM-40 MSA (40km reach with APD receiver and RS(528,514) FEC in host system)",
+	common.ExtCompliance100gBaseDr:    "100G Ethernet: 100GBASE-DR (Clause 140), CAUI-4 (no FEC)",
+	common.ExtCompliance100gFr:        "100G Ethernet: 100G-FR or 100GBASE-FR1 (Clause 140), CAUI-4 (no FEC)",
+	common.ExtCompliance100gLr:        "100G Ethernet: 100G-LR or 100GBASE-LR1 (Clause 140), CAUI-4 (no FEC)",
+	common.ExtComplianceAcc50gaui1e6:  "Active Copper Cable with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M. Providing a worst BER of 10-6 or below",
+	common.ExtComplianceAoc50gaui1e6:  "Active Optical Cable with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M. Providing a worst BER of 10-6 or below",
+	common.ExtComplianceAcc50gaui26e4: "Active Copper Cable with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M. Providing a worst BER of 2.6x10-4 for ACC, 10-5 for AUI, or below",
+	common.ExtComplianceAoc50gaui26e4: "Active Optical Cable with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M. Providing a worst BER of 2.6x10-4 for AOC, 10-5 for AUI, or below",
+	common.ExtCompliance50gBaseCr:     "50GBASE-CR, 100GBASE-CR2, or 200GBASE-CR4",
+	common.ExtCompliance50gBaseSr:     "50GBASE-SR, 100GBASE-SR2, or 200GBASE-SR4",
+	common.ExtCompliance50gBaseFr:     "50GBASE-FR or 200GBASE-DR4",
+	common.ExtCompliance200gBaseFr4:   "200GBASE-FR4",
+	common.ExtCompliance200gPsm4:      "200G 1550 nm PSM4",
+	common.ExtCompliance50gBaseLr:     "50GBASE-LR",
+	common.ExtCompliance200gBaseLr4:   "200GBASE-LR4",
+	0x50:                              "64GFC EA",
+	0x51:                              "64GFC SW",
+	0x52:                              "64GFC LW",
+	0x53:                              "128GFC EA",
+	0x54:                              "128GFC SW",
+	0x55:                              "128GFC LW",
+}
+
+// ethtoolLevels are the flag levels in ethtool's order
+var ethtoolLevels = []string{"high alarm", "low alarm", "high warning", "low warning"}
+
+func levelFlags(c ChannelFlags) []bool {
+	return []bool{c.HighAlarm, c.LowAlarm, c.HighWarning, c.LowWarning}
+}
+
+func onOff(b bool) string {
+	if b {
+		return "On"
+	}
+	return "Off"
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
+
+// ethtoolTransceiverList returns the transceiver types in ethtool's order,
+// byte by byte starting with the most significant bit. The extended
+// compliance code (byte 192) follows the 10G/40G Ethernet codes.
+func (s *Sff8636) ethtoolTransceiverList() []string {
+	var l []string
+	for i := 0; i < len(s.Transceiver); i++ {
+		for bit := 7; bit >= 0; bit-- {
+			k := uint64(1) << (8*i + bit)
+			if n, ok := names[k]; ok && s.Transceiver.Uint64()&k != 0 {
+				l = append(l, n)
+			}
+		}
+		if i == 0 && s.Transceiver[0]&0x80 != 0 {
+			n, ok := ethtoolExtComplianceNames[s.LinkCodes]
+			if !ok {
+				n = "(reserved or unknown)"
+			}
+			l = append(l, n)
+		}
+	}
+	return l
+}
+
+// ethtoolExtIdentifier returns the descriptions of the extended identifier
+// (byte 129) and the high power class enable (byte 93 bit 2)
+func (s *Sff8636) ethtoolExtIdentifier() []string {
+	id := byte(s.ExtIdentifier)
+	cdr := "No CDR in TX,"
+	if id&CdrInTxMask != 0 {
+		cdr = "CDR present in TX,"
+	}
+	if id&CdrInRxMask != 0 {
+		cdr += " CDR present in RX"
+	} else {
+		cdr += " No CDR in RX"
+	}
+	high := "High Power Class (> 3.5 W) not enabled"
+	if s.ControlStatus.IsHighPowerClass5to7Enabled() {
+		high = "High Power Class (> 3.5 W) enabled"
+	}
+	if ext, ok := ethtoolExtPowerClassNames[id&ExtPwrClassMask]; ok {
+		high = ext + " " + high
+	}
+	return []string{ethtoolPowerClassNames[id&PwrClassMask], cdr, high}
+}
+
+// ethtoolMedia renders the transmitter technology and either the laser
+// wavelength or the copper cable attenuation sharing bytes 186-189
+func (s *Sff8636) ethtoolMedia() string {
+	tech := s.DevTech.GetTransmitterTechnology()
+	str := common.EthtoolLine("Transmitter technology", fmt.Sprintf("0x%02x (%s)", byte(tech), s.DevTech.GetTransmitterTechnologyName()))
+	if tech >= TxTechCopperUnequalized {
+		str += common.EthtoolLine("Attenuation at 2.5GHz", fmt.Sprintf("%ddb", s.LaserWavelen[0])) +
+			common.EthtoolLine("Attenuation at 5.0GHz", fmt.Sprintf("%ddb", s.LaserWavelen[1])) +
+			common.EthtoolLine("Attenuation at 7.0GHz", fmt.Sprintf("%ddb", s.LaserWavelenToler[0])) +
+			common.EthtoolLine("Attenuation at 12.9GHz", fmt.Sprintf("%ddb", s.LaserWavelenToler[1]))
+		return str
+	}
+	return str + common.EthtoolLine("Laser wavelength", fmt.Sprintf("%.3fnm", s.LaserWavelen.Nanometers())) +
+		common.EthtoolLine("Laser wavelength tolerance", fmt.Sprintf("%.3fnm", s.LaserWavelenToler.Nanometers()))
+}
+
+// EthtoolString renders the module like "ethtool -m", with the same field
+// names, units and order. Alarm flags and thresholds are included if upper
+// page 03h was read.
+func (s *Sff8636) EthtoolString() string {
+	var b strings.Builder
+	line := func(label, value string) {
+		b.WriteString(common.EthtoolLine(label, value))
+	}
+	withUnit := func(label string, v byte, mult int, unit string) {
+		line(label, fmt.Sprintf("%d%s", int(v)*mult, unit))
+	}
+
+	t := s.Transceiver
+	rev, ok := ethtoolRevisionNames[s.RevisionCompliance]
+	if !ok {
+		rev = "Unallocated"
+	}
+
+	line("Identifier", common.Identifier(s.Identifier).EthtoolString())
+	line("Extended identifier", fmt.Sprintf("0x%02x", byte(s.ExtIdentifier)))
+	b.WriteString(common.EthtoolLines("Extended identifier description", s.ethtoolExtIdentifier()))
+	line("Connector", s.Connector.EthtoolString())
+	line("Transceiver codes", fmt.Sprintf("0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x",
+		t[0], t[1], t[2], t[3], t[4], t[5], t[6], t[7]))
+	b.WriteString(common.EthtoolLines("Transceiver type", s.ethtoolTransceiverList()))
+	line("Encoding", s.Encoding.EthtoolString())
+	withUnit("BR, Nominal", byte(s.BrNominal), 100, "Mbps")
+	line("Rate identifier", fmt.Sprintf("0x%02x", s.RateIdentifier))
+	withUnit("Length (SMF,km)", byte(s.LengthSmf), 1, "km")
+	withUnit("Length (OM3 50um)", byte(s.LengthOm3), 2, "m")
+	withUnit("Length (OM2 50um)", byte(s.LengthOm2), 1, "m")
+	withUnit("Length (OM1 62.5um)", byte(s.LengthOm1), 1, "m")
+	withUnit("Length (Copper or Active cable)", byte(s.LengthCopper), 1, "m")
+	b.WriteString(s.ethtoolMedia())
+	line("Vendor name", common.EthtoolASCII(s.Vendor[:]))
+	line("Vendor OUI", s.VendorOui.EthtoolString())
+	line("Vendor PN", common.EthtoolASCII(s.VendorPn[:]))
+	line("Vendor rev", common.EthtoolASCII(s.VendorRev[:]))
+	line("Vendor SN", common.EthtoolASCII(s.VendorSn[:]))
+	line("Date code", common.EthtoolASCII(s.DateCode[:]))
+	line("Revision Compliance", rev)
+	b.WriteString(s.ethtoolDiagnostics())
+	return b.String()
+}
+
+// ethtoolDiagnostics renders the module and channel monitors like ethtool.
+// Like ethtool, a temperature of 0 or FFFFh is taken as the module not
+// implementing the channel monitors.
+func (s *Sff8636) ethtoolDiagnostics() string {
+	var b strings.Builder
+	line := func(label, value string) {
+		b.WriteString(common.EthtoolLine(label, value))
+	}
+
+	line("Module temperature", s.Temperature.EthtoolString())
+	line("Module voltage", s.SupplyVoltage.EthtoolString())
+	if raw := s.Temperature.Raw(); raw == 0 || raw == -1 {
+		return b.String()
+	}
+
+	line("Alarm/warning flags implemented", yesNo(s.Page03 != nil))
+	c := &s.ChannelMonitoring
+	for i, v := range []common.CurrentMilliAmpBE{c.Tx1Bias, c.Tx2Bias, c.Tx3Bias, c.Tx4Bias} {
+		line(fmt.Sprintf("Laser tx bias current (Channel %d)", i+1), v.EthtoolString())
+	}
+	for i, v := range []common.PowerMilliWattBE{c.Tx1Power, c.Tx2Power, c.Tx3Power, c.Tx4Power} {
+		line(fmt.Sprintf("Transmit avg optical power (Channel %d)", i+1), v.EthtoolString())
+	}
+	rx := "Receiver signal OMA"
+	if s.DiagMonType.IsReceivedPowerMeasurementsTypeAveragePower() {
+		rx = "Rcvr signal avg optical power"
+	}
+	for i, v := range []common.PowerMilliWattBE{c.Rx1Power, c.Rx2Power, c.Rx3Power, c.Rx4Power} {
+		line(fmt.Sprintf("%s(Channel %d)", rx, i+1), v.EthtoolString())
+	}
+
+	p := s.Page03
+	if p == nil {
+		return b.String()
+	}
+	for _, m := range []struct {
+		name  string
+		flags ChannelFlags
+	}{
+		{"Module temperature", monitorFlags(s.ModuleFlags[0] >> 4)},
+		{"Module voltage", monitorFlags(s.ModuleFlags[1] >> 4)},
+	} {
+		for i, f := range levelFlags(m.flags) {
+			line(m.name+" "+ethtoolLevels[i], onOff(f))
+		}
+	}
+	for _, m := range []struct {
+		name, channel string
+		flags         func(int) ChannelFlags
+	}{
+		{"Laser bias current", "Chan", s.ChannelFlags.TxBias},
+		{"Laser tx power", "Channel", s.ChannelFlags.TxPower},
+		{"Rx power", "Channel", s.ChannelFlags.RxPower},
+	} {
+		for ch := 1; ch <= 4; ch++ {
+			for i, f := range levelFlags(m.flags(ch)) {
+				line(fmt.Sprintf("%s %-13s(%s %d)", m.name, ethtoolLevels[i], m.channel, ch), onOff(f))
+			}
+		}
+	}
+	b.WriteString(common.EthtoolThresholds(p.TxBiasThresholds, p.TxPowerThresholds, p.TempThresholds, p.VccThresholds, p.RxPowerThresholds))
+	return b.String()
+}
@@ -0,0 +1,346 @@
+package sff8636
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+// Status represents the status byte (Byte 2)
+type Status byte
+
+const (
+	// Bit 2: Upper memory flat or paged
+	StatusFlatMem Status = 0x04
+	// Bit 1: Digital state of the IntL output pin
+	StatusIntL Status = 0x02
+	// Bit 0: Data_Not_Ready
+	StatusDataNotReady Status = 0x01
+)
+
+// IsFlatMemory returns true if the module only implements upper page 00h
+func (s Status) IsFlatMemory() bool {
+	return s&StatusFlatMem != 0
+}
+
+// IsInterruptDeasserted returns true if the IntL output is high, i.e. no
+// interrupt is pending
+func (s Status) IsInterruptDeasserted() bool {
+	return s&StatusIntL != 0
+}
+
+// IsDataNotReady returns true until the monitor data is valid after power up
+func (s Status) IsDataNotReady() bool {
+	return s&StatusDataNotReady != 0
+}
+
+func (s Status) List() []string {
+	var l []string
+	if s.IsFlatMemory() {
+		l = append(l, "Flat memory")
+	} else {
+		l = append(l, "Paged memory")
+	}
+	if !s.IsInterruptDeasserted() {
+		l = append(l, "Interrupt asserted")
+	}
+	if s.IsDataNotReady() {
+		l = append(l, "Data not ready")
+	}
+	return l
+}
+
+func (s Status) String() string {
+	return strings.Join(s.List(), ", ")
+}
+
+func (s Status) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"flatMemory":          s.IsFlatMemory(),
+		"interruptDeasserted": s.IsInterruptDeasserted(),
+		"dataNotReady":        s.IsDataNotReady(),
+		"hex":                 hex.EncodeToString([]byte{byte(s)}),
+	}
+	return json.Marshal(m)
+}
+
+func (s *Status) UnmarshalJSON(in []byte) error {
+	var b [1]byte
+	if err := unmarshalHex(in, b[:], "Status"); err != nil {
+		return err
+	}
+	*s = Status(b[0])
+	return nil
+}
+
+// ChannelStatusFlags holds the latched per-channel status flags (Bytes 3-5).
+// Channel 1 is bit 0 for Rx flags and bit 4 for Tx flags.
+type ChannelStatusFlags [3]byte
+
+func (f ChannelStatusFlags) bit(i int, channel int) bool {
+	return f[i]>>(channel-1)&1 != 0
+}
+
+// RxLos returns the Rx loss of signal flag of channel (1-4), byte 3 bits 3-0
+func (f ChannelStatusFlags) RxLos(channel int) bool {
+	return f.bit(0, channel)
+}
+
+// TxLos returns the Tx loss of signal flag of channel (1-4), byte 3 bits 7-4
+func (f ChannelStatusFlags) TxLos(channel int) bool {
+	return f.bit(0, channel+4)
+}
+
+// TxFault returns the Tx fault flag of channel (1-4), byte 4 bits 3-0
+func (f ChannelStatusFlags) TxFault(channel int) bool {
+	return f.bit(1, channel)
+}
+
+// TxAdaptEqFault returns the Tx adaptive equalization fault flag of channel
+// (1-4), byte 4 bits 7-4
+func (f ChannelStatusFlags) TxAdaptEqFault(channel int) bool {
+	return f.bit(1, channel+4)
+}
+
+// RxCdrLol returns the Rx CDR loss of lock flag of channel (1-4), byte 5
+// bits 3-0
+func (f ChannelStatusFlags) RxCdrLol(channel int) bool {
+	return f.bit(2, channel)
+}
+
+// TxCdrLol returns the Tx CDR loss of lock flag of channel (1-4), byte 5
+// bits 7-4
+func (f ChannelStatusFlags) TxCdrLol(channel int) bool {
+	return f.bit(2, channel+4)
+}
+
+// List returns the set flags
+func (f ChannelStatusFlags) List() []string {
+	var l []string
+	flags := []struct {
+		name string
+		flag func(int) bool
+	}{
+		{"Rx%d LOS", f.RxLos},
+		{"Tx%d LOS", f.TxLos},
+		{"Tx%d Fault", f.TxFault},
+		{"Tx%d Adaptive EQ Fault", f.TxAdaptEqFault},
+		{"Rx%d CDR LOL", f.RxCdrLol},
+		{"Tx%d CDR LOL", f.TxCdrLol},
+	}
+	for _, fl := range flags {
+		for ch := 1; ch <= 4; ch++ {
+			if fl.flag(ch) {
+				l = append(l, fmt.Sprintf(fl.name, ch))
+			}
+		}
+	}
+	return l
+}
+
+func (f ChannelStatusFlags) String() string {
+	return listOrNone(f.List())
+}
+
+func (f ChannelStatusFlags) MarshalJSON() ([]byte, error) {
+	return marshalFlags(f.List(), f[:])
+}
+
+func (f *ChannelStatusFlags) UnmarshalJSON(in []byte) error {
+	return unmarshalHex(in, f[:], "ChannelStatusFlags")
+}
+
+// ModuleMonitorFlags holds the latched module temperature and supply voltage
+// flags (Bytes 6-7)
+type ModuleMonitorFlags [2]byte
+
+// TemperatureState returns the temperature alarm and warning flags, byte 6
+// bits 7-4
+func (f ModuleMonitorFlags) TemperatureState() common.AlarmState {
+	return monitorFlags(f[0] >> 4).State()
+}
+
+// VccState returns the supply voltage alarm and warning flags, byte 7 bits 7-4
+func (f ModuleMonitorFlags) VccState() common.AlarmState {
+	return monitorFlags(f[1] >> 4).State()
+}
+
+// IsInitComplete returns the Initialization complete flag, byte 6 bit 0
+func (f ModuleMonitorFlags) IsInitComplete() bool {
+	return f[0]&0x01 != 0
+}
+
+// List returns the set flags
+func (f ModuleMonitorFlags) List() []string {
+	var l []string
+	if s := f.TemperatureState(); s != common.AlarmStateNormal {
+		l = append(l, "Temperature "+s.String())
+	}
+	if s := f.VccState(); s != common.AlarmStateNormal {
+		l = append(l, "Vcc "+s.String())
+	}
+	if f.IsInitComplete() {
+		l = append(l, "Initialization complete")
+	}
+	return l
+}
+
+func (f ModuleMonitorFlags) String() string {
+	return listOrNone(f.List())
+}
+
+func (f ModuleMonitorFlags) MarshalJSON() ([]byte, error) {
+	return marshalFlags(f.List(), f[:])
+}
+
+func (f *ModuleMonitorFlags) UnmarshalJSON(in []byte) error {
+	return unmarshalHex(in, f[:], "ModuleMonitorFlags")
+}
+
+// ChannelFlags are the alarm and warning flags, or their masks, of a channel
+// monitor
+type ChannelFlags struct {
+	HighAlarm   bool `json:"highAlarm"`
+	LowAlarm    bool `json:"lowAlarm"`
+	HighWarning bool `json:"highWarning"`
+	LowWarning  bool `json:"lowWarning"`
+}
+
+// monitorFlags decodes the high alarm, low alarm, high warning and low
+// warning flags from bits 3-0 of b
+func monitorFlags(b byte) ChannelFlags {
+	return ChannelFlags{
+		HighAlarm:   b&0x08 != 0,
+		LowAlarm:    b&0x04 != 0,
+		HighWarning: b&0x02 != 0,
+		LowWarning:  b&0x01 != 0,
+	}
+}
+
+// State returns the most severe flag set
+func (c ChannelFlags) State() common.AlarmState {
+	switch {
+	case c.HighAlarm:
+		return common.AlarmStateHighAlarm
+	case c.LowAlarm:
+		return common.AlarmStateLowAlarm
+	case c.HighWarning:
+		return common.AlarmStateHighWarning
+	case c.LowWarning:
+		return common.AlarmStateLowWarning
+	}
+	return common.AlarmStateNormal
+}
+
+// channelFlags returns the flags of channel (1-4) from the two bytes of a
+// channel monitor, the lower numbered channel in bits 7-4
+func channelFlags(b []byte, channel int) ChannelFlags {
+	v := b[(channel-1)/2]
+	if channel%2 == 1 {
+		v >>= 4
+	}
+	return monitorFlags(v)
+}
+
+// channelFlagList lists the flags set in the Rx power, Tx bias and Tx power
+// channel monitor bytes b
+func channelFlagList(b []byte) []string {
+	var l []string
+	for i, name := range []string{"Rx", "Tx Bias", "Tx"} {
+		for ch := 1; ch <= 4; ch++ {
+			c := channelFlags(b[i*2:], ch)
+			if c.HighAlarm {
+				l = append(l, fmt.Sprintf("%s%d High Alarm", name, ch))
+			}
+			if c.LowAlarm {
+				l = append(l, fmt.Sprintf("%s%d Low Alarm", name, ch))
+			}
+			if c.HighWarning {
+				l = append(l, fmt.Sprintf("%s%d High Warning", name, ch))
+			}
+			if c.LowWarning {
+				l = append(l, fmt.Sprintf("%s%d Low Warning", name, ch))
+			}
+		}
+	}
+	return l
+}
+
+// ChannelMonitorFlags holds the latched channel monitor alarm and warning
+// flags (Bytes 9-14), laid out like ChannelMonitorMasks
+type ChannelMonitorFlags [6]byte
+
+// RxPower returns the Rx power flags of channel (1-4), bytes 9-10
+func (f ChannelMonitorFlags) RxPower(channel int) ChannelFlags {
+	return channelFlags(f[0:2], channel)
+}
+
+// TxBias returns the Tx bias flags of channel (1-4), bytes 11-12
+func (f ChannelMonitorFlags) TxBias(channel int) ChannelFlags {
+	return channelFlags(f[2:4], channel)
+}
+
+// TxPower returns the Tx power flags of channel (1-4), bytes 13-14
+func (f ChannelMonitorFlags) TxPower(channel int) ChannelFlags {
+	return channelFlags(f[4:6], channel)
+}
+
+// List returns the set flags
+func (f ChannelMonitorFlags) List() []string {
+	return channelFlagList(f[:])
+}
+
+func (f ChannelMonitorFlags) String() string {
+	return listOrNone(f.List())
+}
+
+func (f ChannelMonitorFlags) MarshalJSON() ([]byte, error) {
+	return marshalFlags(f.List(), f[:])
+}
+
+func (f *ChannelMonitorFlags) UnmarshalJSON(in []byte) error {
+	return unmarshalHex(in, f[:], "ChannelMonitorFlags")
+}
+
+func listOrNone(l []string) string {
+	if len(l) == 0 {
+		return "None"
+	}
+	return strings.Join(l, ", ")
+}
+
+func marshalFlags(l []string, b []byte) ([]byte, error) {
+	v := map[string]interface{}{
+		"value": l,
+		"hex":   hex.EncodeToString(b),
+	}
+	return json.Marshal(v)
+}
+
+// unmarshalHex decodes the "hex" member of in into b
+func unmarshalHex(in []byte, b []byte, name string) error {
+	v := map[string]interface{}{}
+	err := json.Unmarshal(in, &v)
+	if err != nil {
+		return err
+	}
+
+	s, ok := v["hex"].(string)
+	if !ok {
+		return fmt.Errorf("missing hex value for %s type", name)
+	}
+	h, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+
+	if len(h) < len(b) {
+		return fmt.Errorf("length is shorter then %s type", name)
+	}
+
+	copy(b, h)
+	return nil
+}
@@ -0,0 +1,111 @@
+package sff8636
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+func TestFlagsLayout(t *testing.T) {
+	offsets := []struct {
+		name string
+		got  uintptr
+		want uintptr
+	}{
+		{"Status", unsafe.Offsetof(Sff8636{}.Status), 2},
+		{"ChannelStatus", unsafe.Offsetof(Sff8636{}.ChannelStatus), 3},
+		{"ModuleFlags", unsafe.Offsetof(Sff8636{}.ModuleFlags), 6},
+		{"ChannelFlags", unsafe.Offsetof(Sff8636{}.ChannelFlags), 9},
+		{"Temperature", unsafe.Offsetof(Sff8636{}.Temperature), 22},
+	}
+	for _, o := range offsets {
+		if o.got != o.want {
+			t.Errorf("%s at offset %d, want %d", o.name, o.got, o.want)
+		}
+	}
+}
+
+func TestStatus(t *testing.T) {
+	s := StatusFlatMem | StatusDataNotReady
+	if !s.IsFlatMemory() || !s.IsDataNotReady() || s.IsInterruptDeasserted() {
+		t.Errorf("Unexpected status decoding for 0x%02x", byte(s))
+	}
+	if str := s.String(); str != "Flat memory, Interrupt asserted, Data not ready" {
+		t.Errorf("Status.String() = %q", str)
+	}
+	if str := StatusIntL.String(); str != "Paged memory" {
+		t.Errorf("Status.String() = %q", str)
+	}
+}
+
+func TestChannelStatusFlags(t *testing.T) {
+	f := ChannelStatusFlags{0x21, 0x18, 0x80}
+	checks := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"RxLos(1)", f.RxLos(1), true},
+		{"RxLos(2)", f.RxLos(2), false},
+		{"TxLos(2)", f.TxLos(2), true},
+		{"TxFault(4)", f.TxFault(4), true},
+		{"TxAdaptEqFault(1)", f.TxAdaptEqFault(1), true},
+		{"RxCdrLol(4)", f.RxCdrLol(4), false},
+		{"TxCdrLol(4)", f.TxCdrLol(4), true},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %t, want %t", c.name, c.got, c.want)
+		}
+	}
+	if str := f.String(); str != "Rx1 LOS, Tx2 LOS, Tx4 Fault, Tx1 Adaptive EQ Fault, Tx4 CDR LOL" {
+		t.Errorf("ChannelStatusFlags.String() = %q", str)
+	}
+	if str := (ChannelStatusFlags{}).String(); str != "None" {
+		t.Errorf("ChannelStatusFlags{}.String() = %q, want None", str)
+	}
+}
+
+func TestModuleMonitorFlags(t *testing.T) {
+	f := ModuleMonitorFlags{0x51, 0x20}
+	if s := f.TemperatureState(); s != common.AlarmStateLowAlarm {
+		t.Errorf("TemperatureState() = %v, want %v", s, common.AlarmStateLowAlarm)
+	}
+	if s := f.VccState(); s != common.AlarmStateHighWarning {
+		t.Errorf("VccState() = %v, want %v", s, common.AlarmStateHighWarning)
+	}
+	if !f.IsInitComplete() {
+		t.Error("IsInitComplete() = false, want true")
+	}
+}
+
+func TestChannelMonitorFlags(t *testing.T) {
+	f := ChannelMonitorFlags{0x00, 0x02, 0x40, 0x00, 0x00, 0x88}
+	if c := f.RxPower(4); !c.HighWarning || c.State() != common.AlarmStateHighWarning {
+		t.Errorf("RxPower(4) = %+v", c)
+	}
+	if c := f.TxBias(1); !c.LowAlarm || c.State() != common.AlarmStateLowAlarm {
+		t.Errorf("TxBias(1) = %+v", c)
+	}
+	if c := f.TxPower(3); c.State() != common.AlarmStateHighAlarm {
+		t.Errorf("TxPower(3) = %+v", c)
+	}
+	if c := f.RxPower(1); c.State() != common.AlarmStateNormal {
+		t.Errorf("RxPower(1) = %+v", c)
+	}
+	if l := strings.Join(f.List(), ", "); l != "Rx4 High Warning, Tx Bias1 Low Alarm, Tx3 High Alarm, Tx4 High Alarm" {
+		t.Errorf("ChannelMonitorFlags.List() = %q", l)
+	}
+
+	b, err := json.Marshal(f)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var got ChannelMonitorFlags
+	if err := json.Unmarshal(b, &got); err != nil || got != f {
+		t.Errorf("JSON round trip = % x, %v, want % x", got, err, f)
+	}
+}
@@ -0,0 +1,62 @@
+package sff8636
+
+import "github.com/bluecmd/go-sff/common"
+
+// Inventory returns the vendor independent identification of the module
+func (s *Sff8636) Inventory() common.Inventory {
+	inv := common.Inventory{
+		Identifier:   s.IdentifierPage01,
+		Vendor:       s.Vendor.String(),
+		OUI:          s.VendorOui,
+		PartNumber:   s.VendorPn.String(),
+		Revision:     s.VendorRev.String(),
+		SerialNumber: s.VendorSn.String(),
+		DateCode:     s.DateCode,
+		Connector:    s.Connector,
+		BitRate:      int(s.BrNominal) * 100,
+		LaneCount:    4,
+		MediaType:    s.mediaType(),
+		Reach: common.Reach{
+			Smf: float64(s.LengthSmf) * 1000,
+			Om3: float64(s.LengthOm3) * 2,
+			Om2: float64(s.LengthOm2),
+			Om1: float64(s.LengthOm1),
+		},
+	}
+	// Byte 140 FFh means the bit rate is in byte 222, in units of 250 Mb/s
+	if s.BrNominal == 0xFF {
+		inv.BitRate = int(s.BrNominalExt) * 250
+	}
+	// Byte 146 is the cable length in m for cable assemblies and the OM4
+	// length in units of 2 m otherwise. Bytes 186-187 hold the copper cable
+	// attenuation instead of the wavelength for copper cables.
+	if s.isCopper() {
+		inv.Reach.Copper = float64(s.LengthCopper)
+	} else {
+		inv.Reach.Om4 = float64(s.LengthCopper) * 2
+		inv.Wavelength = s.LaserWavelen.Nanometers()
+	}
+	return inv
+}
+
+// isCopper returns true for copper cable transmitter technologies (byte 147
+// bits 7-4)
+func (s *Sff8636) isCopper() bool {
+	return s.DevTech.GetTransmitterTechnology() >= TxTechCopperUnequalized
+}
+
+// mediaType derives the media type from the device technology and the
+// supported link lengths
+func (s *Sff8636) mediaType() common.MediaType {
+	switch tech := s.DevTech.GetTransmitterTechnology(); {
+	case tech == TxTechCopperUnequalized || tech == TxTechCopperPassiveEqualized:
+		return common.MediaTypePassiveCopper
+	case s.isCopper():
+		return common.MediaTypeActiveCable
+	case s.LengthSmf != 0:
+		return common.MediaTypeSmf
+	case s.LengthOm3 != 0 || s.LengthOm2 != 0 || s.LengthOm1 != 0 || s.LengthCopper != 0:
+		return common.MediaTypeMmf
+	}
+	return common.MediaTypeUndefined
+}
@@ -9,29 +9,41 @@ import (
 )
 
 const (
-	red     = "\x1b[31m"
-	green   = "\x1b[32m"
-	yellow  = "\x1b[33m"
-	blue    = "\x1b[34m"
-	magenta = "\x1b[35m"
-	cyan    = "\x1b[36m"
-	white   = "\x1b[37m"
-	clear   = "\x1b[0m"
+	red     = common.ColorRed
+	green   = common.ColorGreen
+	yellow  = common.ColorYellow
+	blue    = common.ColorBlue
+	magenta = common.ColorMagenta
+	cyan    = common.ColorCyan
+	white   = common.ColorWhite
+	clear   = common.ColorClear
 )
 
 type Sff8636 struct {
 	// Page 00h (Bytes 0-127)
 	Identifier         byte                     `json:"identifier"`         // Byte 0: Identifier
 	RevisionCompliance RevisionCompliance       `json:"revisionCompliance"` // Byte 1: Revision Compliance
-	_                  [20]byte                 `json:"-"`                  // Bytes 2-21
+	Status             Status                   `json:"status"`             // Byte 2: Status
+	ChannelStatus      ChannelStatusFlags       `json:"channelStatus"`      // Bytes 3-5: Channel Status Interrupt Flags
+	ModuleFlags        ModuleMonitorFlags       `json:"moduleFlags"`        // Bytes 6-7: Module Monitor Interrupt Flags
+	_                  byte                     `json:"-"`                  // Byte 8: Vendor Specific
+	ChannelFlags       ChannelMonitorFlags      `json:"channelFlags"`       // Bytes 9-14: Channel Monitor Interrupt Flags
+	_                  [7]byte                  `json:"-"`                  // Bytes 15-21: Reserved channel monitor flags, no lane flags defined
 	Temperature        common.TemperatureQ8_8BE `json:"temperature"`        // Bytes 22-23: Temperature
 	_                  [2]byte                  `json:"-"`                  // Bytes 24-25
 	SupplyVoltage      common.VoltageVoltBE     `json:"supplyVoltage"`      // Bytes 26-27: Supply Voltage
 	_                  [6]byte                  `json:"-"`                  // Bytes 28-33
 	ChannelMonitoring  ChannelMonitoring        `json:"channelMonitoring"`  // Bytes 34-57: Channel Monitoring
-	_                  [35]byte                 `json:"-"`                  // Bytes 58-92
+	_                  [28]byte                 `json:"-"`                  // Bytes 58-85
+	TxDisable          TxDisable                `json:"txDisable"`          // Byte 86: Tx Disable
+	RxRateSelect       RateSelect               `json:"rxRateSelect"`       // Byte 87: Rx Rate Select
+	TxRateSelect       RateSelect               `json:"txRateSelect"`       // Byte 88: Tx Rate Select
+	RxAppSelect        ApplicationSelect        `json:"rxAppSelect"`        // Bytes 89-92: Rx4-Rx1 Application Select
 	ControlStatus      ControlStatus            `json:"controlStatus"`      // Byte 93: Control and Status
-	_                  [34]byte                 `json:"-"`                  // Bytes 94-127
+	TxAppSelect        ApplicationSelect        `json:"txAppSelect"`        // Bytes 94-97: Tx4-Tx1 Application Select
+	CdrControl         CdrControl               `json:"cdrControl"`         // Byte 98: CDR Control
+	SignalControl      SignalControl            `json:"signalControl"`      // Byte 99: LPMode/TxDis and IntL/LOSL Control
+	_                  [28]byte                 `json:"-"`                  // Bytes 100-127
 
 	// Page 01h (Bytes 128-255)
 	IdentifierPage01  common.Identifier            `json:"identifierPage01"`         // 128 - Identifier
@@ -65,35 +77,137 @@ type Sff8636 struct {
 	BrNominalExt      byte                         `json:"-"`                        // 222 - BR, Nominal
 	CcExt             byte                         `json:"-"`                        // 223 - CC_EXT
 	VendorSpec        [32]byte                     `json:"-"`                        // 224-255 - Vendor Specific
+
+	// Page 03h (Bytes 128-255), nil on flat memory modules, if not read or if
+	// all zero
+	Page03 *Page03 `json:"page03,omitempty"`
+
+	regions []common.Region
+	image   []byte // Flat dump s was decoded from, see Encode
 }
 
+// mappedLen is the number of bytes of Sff8636 mapped onto the lower page and
+// upper page 00h
+const mappedLen = 256
+
+// Decode decodes the lower page and upper page 00h from eeprom. Upper page
+// 03h is decoded as well if eeprom holds the 640 byte flat layout of a paged
+// module. eeprom is copied, so the caller may reuse it.
 func Decode(eeprom []byte) (*Sff8636, error) {
-	if len(eeprom) < 256 {
-		return nil, fmt.Errorf("eeprom size to small needs to be 256 bytes or larger got: %d bytes", len(eeprom))
+	m, err := common.ParseFlatSff8636(eeprom)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeMemory(m)
+}
+
+var (
+	ccBase = common.CheckCode{Name: "CC_BASE", Start: 128, Offset: 191}
+	ccExt  = common.CheckCode{Name: "CC_EXT", Start: 192, Offset: 223}
+)
+
+// bytes relies on the memory map filling exactly the first mappedLen bytes of
+// Sff8636, with Page03 after it. Fails to compile otherwise.
+var _ [mappedLen]byte = [unsafe.Offsetof(Sff8636{}.Page03)]byte{}
+
+// bytes returns the lower page and upper page 00h backing s
+func (s *Sff8636) bytes() []byte {
+	return (*[mappedLen]byte)(unsafe.Pointer(s))[:]
+}
+
+// Validate verifies the CC_BASE (byte 191) and CC_EXT (byte 223) check codes.
+// Mismatches are reported as *common.ChecksumError, or common.ChecksumErrors
+// if both check codes are wrong.
+func (s *Sff8636) Validate() error {
+	return common.VerifyCheckCodes(s.bytes(), ccBase, ccExt)
+}
+
+// UpdateChecksums recomputes CC_BASE and CC_EXT from the current contents
+func (s *Sff8636) UpdateChecksums() {
+	ccBase.Update(s.bytes())
+	ccExt.Update(s.bytes())
+}
+
+// flagColor returns red for raised flags
+func flagColor(raised bool) string {
+	if raised {
+		return red
 	}
+	return green
+}
+
+// checkCodeColor returns red for mismatching check codes
+func checkCodeColor(c common.CheckCode, b []byte) string {
+	if c.Verify(b) != nil {
+		return red
+	}
+	return green
+}
 
+// DecodeMemory decodes the lower page and upper page 00h from paged module
+// memory, and upper page 03h if present
+func DecodeMemory(m *common.Memory) (*Sff8636, error) {
+	b, err := m.Read(common.Region{Address: common.AddressA0, Offset: 0, Length: mappedLen})
+	if err != nil {
+		return nil, fmt.Errorf("lower page or upper page 00h not present: %w", err)
+	}
 	// Check if this is a valid SFF-8636 EEPROM by checking the identifier at byte 128
-	if eeprom[128] == 12 || eeprom[128] == 13 || eeprom[128] == 17 {
-		return (*Sff8636)(unsafe.Pointer(&eeprom[0])), nil
+	if !(b[128] == 12 || b[128] == 13 || b[128] == 17) {
+		return nil, fmt.Errorf("unknown eeprom standard, identifier: 0x%02x", b[128])
 	}
 
-	return nil, fmt.Errorf("unknown eeprom standard, identifier: 0x%02x", byte(eeprom[128]))
+	s := &Sff8636{regions: m.Regions(), image: flatImage(m)}
+	copy(s.bytes(), b)
+	// Flat memory modules only implement upper page 00h (byte 2 bit 2). A
+	// page 03h of zeros is what readers return for a page the module does not
+	// implement, it holds no thresholds.
+	if b, err := m.Read(common.UpperPage(common.AddressA0, 0, 3)); err == nil && !s.Status.IsFlatMemory() && !isZero(b) {
+		s.Page03 = &Page03{}
+		copy(s.Page03.bytes(), b)
+	}
+	return s, nil
+}
+
+// isZero returns true if all bytes of b are zero
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Regions returns the half pages present in the memory s was decoded from
+func (s *Sff8636) Regions() []common.Region {
+	return s.regions
 }
 
 func (s *Sff8636) String() string {
 	var result strings.Builder
 	result.WriteString(fmt.Sprintf("%-50s : 0x%02x\n", "Identifier [0]", s.Identifier))
 	result.WriteString(fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Revision Compliance [1]", byte(s.RevisionCompliance), s.RevisionCompliance))
+	result.WriteString(fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Status [2]", byte(s.Status), s.Status))
+	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Channel Status Flags [3-5]", s.ChannelStatus))
+	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Module Monitor Flags [6-7]", s.ModuleFlags))
+	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Channel Monitor Flags [9-14]", s.ChannelFlags))
 	result.WriteString(fmt.Sprintf("%-50s :\n%s\n", "Channel Monitoring [34-81]", s.ChannelMonitoring.String()))
 	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Temperature [22-23]", s.Temperature))
 	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Supply Voltage [26-27]", s.SupplyVoltage))
+	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Tx Disable [86]", s.TxDisable))
+	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Rx Rate Select [87]", s.RxRateSelect))
+	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Tx Rate Select [88]", s.TxRateSelect))
+	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Rx Application Select [89-92]", s.RxAppSelect))
 	result.WriteString(fmt.Sprintf("%-50s : 0x%02x\n%s\n", "Control Status [93]", byte(s.ControlStatus), s.ControlStatus))
+	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Tx Application Select [94-97]", s.TxAppSelect))
+	result.WriteString(fmt.Sprintf("%-50s : %s\n", "CDR Control [98]", s.CdrControl))
+	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Signal Control [99]", s.SignalControl))
 	result.WriteString(fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Identifier [128]", byte(s.IdentifierPage01), s.IdentifierPage01))
 	result.WriteString(fmt.Sprintf("%-50s : 0x%02x\n", "Extended Identifier [129]", byte(s.ExtIdentifier)))
 	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Extended Identifier Description", strings.Join(s.ExtIdentifier.List(), fmt.Sprintf("\n%-50s : ", " "))))
 	result.WriteString(fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Connector [130]", byte(s.Connector), s.Connector))
 	result.WriteString(fmt.Sprintf("%-50s : 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x\n", "Transceiver Codes [131-138]", s.Transceiver[0], s.Transceiver[1], s.Transceiver[2], s.Transceiver[3], s.Transceiver[4], s.Transceiver[5], s.Transceiver[6], s.Transceiver[7]))
-	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Transceiver Type", strings.Join(s.Transceiver.List(), fmt.Sprintf("\n%-50s : ", " "))))
+	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Transceiver Type", strings.Join(s.TransceiverList(), fmt.Sprintf("\n%-50s : ", " "))))
 	result.WriteString(fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Encoding [139]", byte(s.Encoding), s.Encoding))
 	result.WriteString(fmt.Sprintf("%-50s : %s\n", "BR, Nominal [140]", s.BrNominal))
 	result.WriteString(fmt.Sprintf("%-50s : 0x%02x\n", "Rate Identifier [141]", s.RateIdentifier))
@@ -109,11 +223,19 @@ func (s *Sff8636) String() string {
 	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Vendor Rev [184-185]", s.VendorRev))
 	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Wavelength [186-187]", s.LaserWavelen))
 	result.WriteString(fmt.Sprintf("%-50s : %s\n", "  Tolerance [188-189]", s.LaserWavelenToler))
+	result.WriteString(fmt.Sprintf("%-50s : %s\n", "CC_BASE [191]", ccBase.Status(s.bytes())))
 	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Option Values [193-195]", s.Options.String()))
 	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Diagnostic Monitoring Type [220]", s.DiagMonType.String()))
 	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Enhanced Options [221]", s.EnhOptions.String()))
+	result.WriteString(fmt.Sprintf("%-50s : %s\n", "CC_EXT [223]", ccExt.Status(s.bytes())))
 	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Vendor SN [196-211]", s.VendorSn))
 	result.WriteString(fmt.Sprintf("%-50s : %s\n", "Date Code [212-219]", s.DateCode))
+	for _, l := range s.page03() {
+		result.WriteString(fmt.Sprintf("%-50s : %s\n", l[0], l[1]))
+	}
+	for _, c := range s.ChannelStates() {
+		result.WriteString(fmt.Sprintf("%-50s : %s\n", fmt.Sprintf("Channel %d Alarm State", c.Channel), c))
+	}
 
 	return result.String()
 }
@@ -137,6 +259,10 @@ func (s *Sff8636) StringCol() string {
 	var result strings.Builder
 	result.WriteString(strCol("Identifier [0]", fmt.Sprintf("0x%02x", s.Identifier), cyan, green))
 	result.WriteString(strCol("Revision Compliance [1]", fmt.Sprintf("0x%02x (%s)", byte(s.RevisionCompliance), s.RevisionCompliance), cyan, green))
+	result.WriteString(strCol("Status [2]", fmt.Sprintf("0x%02x (%s)", byte(s.Status), s.Status), cyan, flagColor(s.Status.IsDataNotReady())))
+	result.WriteString(strCol("Channel Status Flags [3-5]", s.ChannelStatus.String(), cyan, flagColor(len(s.ChannelStatus.List()) > 0)))
+	result.WriteString(strCol("Module Monitor Flags [6-7]", s.ModuleFlags.String(), cyan, flagColor(s.ModuleFlags.TemperatureState() != common.AlarmStateNormal || s.ModuleFlags.VccState() != common.AlarmStateNormal)))
+	result.WriteString(strCol("Channel Monitor Flags [9-14]", s.ChannelFlags.String(), cyan, flagColor(len(s.ChannelFlags.List()) > 0)))
 	result.WriteString(strCol("Channel Monitoring [34-81]", "", cyan, yellow))
 	result.WriteString(strCol("  Rx1 Power", s.ChannelMonitoring.Rx1Power.String(), cyan, green))
 	result.WriteString(strCol("  Rx2 Power", s.ChannelMonitoring.Rx2Power.String(), cyan, green))
@@ -152,17 +278,25 @@ func (s *Sff8636) StringCol() string {
 	result.WriteString(strCol("  Tx4 Power", s.ChannelMonitoring.Tx4Power.String(), cyan, green))
 	result.WriteString(strCol("Temperature [22-23]", s.Temperature.String(), cyan, green))
 	result.WriteString(strCol("Supply Voltage [26-27]", s.SupplyVoltage.String(), cyan, green))
+	result.WriteString(strCol("Tx Disable [86]", s.TxDisable.String(), cyan, flagColor(s.TxDisable != 0)))
+	result.WriteString(strCol("Rx Rate Select [87]", s.RxRateSelect.String(), cyan, green))
+	result.WriteString(strCol("Tx Rate Select [88]", s.TxRateSelect.String(), cyan, green))
+	result.WriteString(strCol("Rx Application Select [89-92]", s.RxAppSelect.String(), cyan, green))
 	result.WriteString(strCol("Control Status [93]", fmt.Sprintf("0x%02x", byte(s.ControlStatus)), cyan, green))
 	result.WriteString(strCol("  Software Reset", fmt.Sprintf("%t", s.ControlStatus.IsSoftwareReset()), cyan, green))
 	result.WriteString(strCol("  High Power Class 8", fmt.Sprintf("%t", s.ControlStatus.IsHighPowerClass8Enabled()), cyan, green))
 	result.WriteString(strCol("  High Power Classes 5-7", fmt.Sprintf("%t", s.ControlStatus.IsHighPowerClass5to7Enabled()), cyan, green))
 	result.WriteString(strCol("  Low Power Mode", fmt.Sprintf("%t", s.ControlStatus.IsLowPowerMode()), cyan, green))
+	result.WriteString(strCol("  Power Override", fmt.Sprintf("%t", s.ControlStatus.IsPowerOverride()), cyan, green))
+	result.WriteString(strCol("Tx Application Select [94-97]", s.TxAppSelect.String(), cyan, green))
+	result.WriteString(strCol("CDR Control [98]", s.CdrControl.String(), cyan, green))
+	result.WriteString(strCol("Signal Control [99]", s.SignalControl.String(), cyan, green))
 	result.WriteString(strCol("Identifier [128]", fmt.Sprintf("0x%02x (%s)", byte(s.IdentifierPage01), s.IdentifierPage01), cyan, green))
 	result.WriteString(strCol("Extended Identifier [129]", fmt.Sprintf("0x%02x", byte(s.ExtIdentifier)), cyan, green))
 	result.WriteString(strCol("Extended Identifier Description", strings.Join(s.ExtIdentifier.List(), fmt.Sprintf("\n%-50s : ", " ")), cyan, green))
 	result.WriteString(strCol("Connector [130]", fmt.Sprintf("0x%02x (%s)", byte(s.Connector), s.Connector), cyan, green))
 	result.WriteString(strCol("Transceiver Codes [131-138]", fmt.Sprintf("0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x", s.Transceiver[0], s.Transceiver[1], s.Transceiver[2], s.Transceiver[3], s.Transceiver[4], s.Transceiver[5], s.Transceiver[6], s.Transceiver[7]), cyan, green))
-	result.WriteString(joinStrCol("Transceiver Type", s.Transceiver.List(), cyan, yellow))
+	result.WriteString(joinStrCol("Transceiver Type", s.TransceiverList(), cyan, yellow))
 	result.WriteString(strCol("Encoding [139]", fmt.Sprintf("0x%02x (%s)", byte(s.Encoding), s.Encoding), cyan, green))
 	result.WriteString(strCol("BR, Nominal [140]", s.BrNominal.String(), cyan, green))
 	result.WriteString(strCol("Rate Identifier [141]", fmt.Sprintf("0x%02x", s.RateIdentifier), cyan, green))
@@ -183,11 +317,23 @@ func (s *Sff8636) StringCol() string {
 	result.WriteString(strCol("Vendor Rev [184-185]", s.VendorRev.String(), cyan, green))
 	result.WriteString(strCol("Wavelength [186-187]", s.LaserWavelen.String(), cyan, green))
 	result.WriteString(strCol("  Tolerance [188-189]", s.LaserWavelenToler.String(), cyan, green))
+	result.WriteString(strCol("CC_BASE [191]", ccBase.Status(s.bytes()), cyan, checkCodeColor(ccBase, s.bytes())))
 	result.WriteString(strCol("Option Values [193-195]", s.Options.String(), cyan, green))
 	result.WriteString(strCol("Diagnostic Monitoring Type [220]", s.DiagMonType.String(), cyan, green))
 	result.WriteString(strCol("Enhanced Options [221]", s.EnhOptions.String(), cyan, green))
+	result.WriteString(strCol("CC_EXT [223]", ccExt.Status(s.bytes()), cyan, checkCodeColor(ccExt, s.bytes())))
 	result.WriteString(strCol("Vendor SN [196-211]", s.VendorSn.String(), cyan, green))
 	result.WriteString(strCol("Date Code [212-219]", s.DateCode.String(), cyan, green))
+	for _, l := range s.page03() {
+		result.WriteString(strCol(l[0], l[1], cyan, green))
+	}
+	for _, c := range s.ChannelStates() {
+		col := green
+		if len(c.List()) > 0 {
+			col = red
+		}
+		result.WriteString(strCol(fmt.Sprintf("Channel %d Alarm State", c.Channel), c.String(), cyan, col))
+	}
 
 	return result.String()
 }
@@ -51,6 +51,15 @@ func (r RevisionCompliance) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m)
 }
 
+func (r *RevisionCompliance) UnmarshalJSON(in []byte) error {
+	var b [1]byte
+	if err := unmarshalHex(in, b[:], "RevisionCompliance"); err != nil {
+		return err
+	}
+	*r = RevisionCompliance(b[0])
+	return nil
+}
+
 // ChannelMonitoring represents the channel monitoring values (Bytes 34-57)
 type ChannelMonitoring struct {
 	Rx1Power common.PowerMilliWattBE  `json:"rx1Power"` // Bytes 34-35: Rx1 Power MSB/LSB
@@ -152,3 +161,12 @@ func (c ControlStatus) MarshalJSON() ([]byte, error) {
 	}
 	return json.Marshal(m)
 }
+
+func (c *ControlStatus) UnmarshalJSON(in []byte) error {
+	var b [1]byte
+	if err := unmarshalHex(in, b[:], "ControlStatus"); err != nil {
+		return err
+	}
+	*c = ControlStatus(b[0])
+	return nil
+}
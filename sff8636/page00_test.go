@@ -140,3 +140,29 @@ func TestSff8636StructSize(t *testing.T) {
 	t.Logf("ControlStatus offset: %d", unsafe.Offsetof(sff.ControlStatus))
 	t.Logf("IdentifierPage01 offset: %d", unsafe.Offsetof(sff.IdentifierPage01))
 }
+
+func TestEthtoolExtendedCompliance(t *testing.T) {
+	b := make([]byte, 256)
+	b[0], b[128] = 0x11, 0x11
+	b[131] = 0x80 // Extended compliance in byte 192
+
+	tests := []struct {
+		code byte
+		want string
+	}{
+		{0x02, "100G Ethernet: 100G Base-SR4 or 25GBase-SR"},
+		{0x0c, "25G Ethernet: 25G Base-CR CA-S"},
+		{0x00, "(reserved or unknown)"},
+		{0x0a, "(reserved or unknown)"},
+	}
+	for _, test := range tests {
+		b[192] = test.code
+		s, err := Decode(b)
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if got := s.ethtoolTransceiverList(); len(got) != 1 || got[0] != test.want {
+			t.Errorf("ethtoolTransceiverList() = %q for code 0x%02x, want %q", got, test.code, test.want)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package sff8636
+
+import (
+	"strings"
+	"unsafe"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+// Page03 holds the module and channel thresholds and the channel monitor
+// masks of upper page 03h (bytes 128-255)
+type Page03 struct {
+	TempThresholds    common.TemperatureThresholds `json:"tempThresholds"`    // 128-135 - Temperature
+	_                 [8]byte                      `json:"-"`                 // 136-143 - Reserved
+	VccThresholds     common.VoltageThresholds     `json:"vccThresholds"`     // 144-151 - Supply voltage
+	_                 [8]byte                      `json:"-"`                 // 152-159 - Reserved
+	_                 [16]byte                     `json:"-"`                 // 160-175 - Vendor specific
+	RxPowerThresholds common.PowerThresholds       `json:"rxPowerThresholds"` // 176-183 - Rx power
+	TxBiasThresholds  common.CurrentThresholds     `json:"txBiasThresholds"`  // 184-191 - Tx bias
+	TxPowerThresholds common.PowerThresholds       `json:"txPowerThresholds"` // 192-199 - Tx power
+	_                 [8]byte                      `json:"-"`                 // 200-207 - Reserved
+	_                 [16]byte                     `json:"-"`                 // 208-223 - Vendor specific
+	_                 [18]byte                     `json:"-"`                 // 224-241 - Optional channel controls
+	ChannelMasks      ChannelMonitorMasks          `json:"channelMasks"`      // 242-247 - Channel monitor masks
+	_                 [8]byte                      `json:"-"`                 // 248-255 - Reserved
+}
+
+// bytes relies on Page03 being exactly one page. Fails to compile otherwise.
+var _ [common.PageLen]byte = [unsafe.Sizeof(Page03{})]byte{}
+
+func (p *Page03) bytes() []byte {
+	return (*[common.PageLen]byte)(unsafe.Pointer(p))[:]
+}
+
+// ChannelMonitorMasks masks the channel monitor interrupt flags (bytes
+// 242-247), laid out like ChannelMonitorFlags
+type ChannelMonitorMasks [6]byte
+
+// RxPower returns the Rx power mask of channel (1-4), bytes 242-243
+func (m ChannelMonitorMasks) RxPower(channel int) ChannelFlags {
+	return channelFlags(m[0:2], channel)
+}
+
+// TxBias returns the Tx bias mask of channel (1-4), bytes 244-245
+func (m ChannelMonitorMasks) TxBias(channel int) ChannelFlags {
+	return channelFlags(m[2:4], channel)
+}
+
+// TxPower returns the Tx power mask of channel (1-4), bytes 246-247
+func (m ChannelMonitorMasks) TxPower(channel int) ChannelFlags {
+	return channelFlags(m[4:6], channel)
+}
+
+// List returns the masked flags
+func (m ChannelMonitorMasks) List() []string {
+	return channelFlagList(m[:])
+}
+
+func (m ChannelMonitorMasks) String() string {
+	return listOrNone(m.List())
+}
+
+func (m ChannelMonitorMasks) MarshalJSON() ([]byte, error) {
+	return marshalFlags(m.List(), m[:])
+}
+
+func (m *ChannelMonitorMasks) UnmarshalJSON(in []byte) error {
+	return unmarshalHex(in, m[:], "ChannelMonitorMasks")
+}
+
+// ChannelState is the alarm state of the monitors of a channel, relative to
+// the page 03h thresholds
+type ChannelState struct {
+	Channel int               `json:"channel"`
+	RxPower common.AlarmState `json:"rxPower"`
+	TxBias  common.AlarmState `json:"txBias"`
+	TxPower common.AlarmState `json:"txPower"`
+}
+
+// List returns the monitors at the alarm or warning level
+func (c ChannelState) List() []string {
+	var l []string
+	if c.RxPower.IsAlarm() || c.RxPower.IsWarning() {
+		l = append(l, "Rx Power "+c.RxPower.String())
+	}
+	if c.TxBias.IsAlarm() || c.TxBias.IsWarning() {
+		l = append(l, "Tx Bias "+c.TxBias.String())
+	}
+	if c.TxPower.IsAlarm() || c.TxPower.IsWarning() {
+		l = append(l, "Tx Power "+c.TxPower.String())
+	}
+	return l
+}
+
+func (c ChannelState) String() string {
+	l := c.List()
+	if len(l) == 0 {
+		return "Normal"
+	}
+	return strings.Join(l, ", ")
+}
+
+// ChannelStates returns the alarm state of the channel monitors of all four
+// channels, or nil if page 03h is not present. Monitors whose thresholds are
+// all zero are reported as common.AlarmStateNone.
+func (s *Sff8636) ChannelStates() []ChannelState {
+	if s.Page03 == nil {
+		return nil
+	}
+	p := s.Page03
+	c := &s.ChannelMonitoring
+	rx := []common.PowerMilliWattBE{c.Rx1Power, c.Rx2Power, c.Rx3Power, c.Rx4Power}
+	bias := []common.CurrentMilliAmpBE{c.Tx1Bias, c.Tx2Bias, c.Tx3Bias, c.Tx4Bias}
+	tx := []common.PowerMilliWattBE{c.Tx1Power, c.Tx2Power, c.Tx3Power, c.Tx4Power}
+
+	states := make([]ChannelState, 4)
+	for i := range states {
+		states[i] = ChannelState{
+			Channel: i + 1,
+			RxPower: p.RxPowerThresholds.State(rx[i]),
+			TxBias:  p.TxBiasThresholds.State(bias[i]),
+			TxPower: p.TxPowerThresholds.State(tx[i]),
+		}
+	}
+	return states
+}
+
+// page03 returns the page 03h threshold and mask output lines, or nil if
+// page 03h is not present
+func (s *Sff8636) page03() [][2]string {
+	p := s.Page03
+	if p == nil {
+		return nil
+	}
+	l := [][2]string{
+		{"Temperature High Alarm [03h 128-129]", p.TempThresholds.HighAlarm.String()},
+		{"Temperature Low Alarm [03h 130-131]", p.TempThresholds.LowAlarm.String()},
+		{"Temperature High Warning [03h 132-133]", p.TempThresholds.HighWarning.String()},
+		{"Temperature Low Warning [03h 134-135]", p.TempThresholds.LowWarning.String()},
+		{"Vcc High Alarm [03h 144-145]", p.VccThresholds.HighAlarm.String()},
+		{"Vcc Low Alarm [03h 146-147]", p.VccThresholds.LowAlarm.String()},
+		{"Vcc High Warning [03h 148-149]", p.VccThresholds.HighWarning.String()},
+		{"Vcc Low Warning [03h 150-151]", p.VccThresholds.LowWarning.String()},
+		{"Rx Power High Alarm [03h 176-177]", p.RxPowerThresholds.HighAlarm.String()},
+		{"Rx Power Low Alarm [03h 178-179]", p.RxPowerThresholds.LowAlarm.String()},
+		{"Rx Power High Warning [03h 180-181]", p.RxPowerThresholds.HighWarning.String()},
+		{"Rx Power Low Warning [03h 182-183]", p.RxPowerThresholds.LowWarning.String()},
+		{"Tx Bias High Alarm [03h 184-185]", p.TxBiasThresholds.HighAlarm.String()},
+		{"Tx Bias Low Alarm [03h 186-187]", p.TxBiasThresholds.LowAlarm.String()},
+		{"Tx Bias High Warning [03h 188-189]", p.TxBiasThresholds.HighWarning.String()},
+		{"Tx Bias Low Warning [03h 190-191]", p.TxBiasThresholds.LowWarning.String()},
+		{"Tx Power High Alarm [03h 192-193]", p.TxPowerThresholds.HighAlarm.String()},
+		{"Tx Power Low Alarm [03h 194-195]", p.TxPowerThresholds.LowAlarm.String()},
+		{"Tx Power High Warning [03h 196-197]", p.TxPowerThresholds.HighWarning.String()},
+		{"Tx Power Low Warning [03h 198-199]", p.TxPowerThresholds.LowWarning.String()},
+		{"Channel Monitor Masks [03h 242-247]", p.ChannelMasks.String()},
+	}
+	return l
+}
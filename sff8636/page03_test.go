@@ -0,0 +1,133 @@
+package sff8636
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/bluecmd/go-sff/common"
+)
+
+func TestPage03Layout(t *testing.T) {
+	if size := unsafe.Sizeof(Page03{}); size != common.PageLen {
+		t.Errorf("Page03 is %d bytes, want %d", size, common.PageLen)
+	}
+	offsets := []struct {
+		name string
+		got  uintptr
+		want uintptr
+	}{
+		{"VccThresholds", unsafe.Offsetof(Page03{}.VccThresholds), 144 - 128},
+		{"RxPowerThresholds", unsafe.Offsetof(Page03{}.RxPowerThresholds), 176 - 128},
+		{"TxBiasThresholds", unsafe.Offsetof(Page03{}.TxBiasThresholds), 184 - 128},
+		{"TxPowerThresholds", unsafe.Offsetof(Page03{}.TxPowerThresholds), 192 - 128},
+		{"ChannelMasks", unsafe.Offsetof(Page03{}.ChannelMasks), 242 - 128},
+	}
+	for _, o := range offsets {
+		if o.got != o.want {
+			t.Errorf("%s at offset %d, want %d", o.name, o.got, o.want)
+		}
+	}
+}
+
+// page03Eeprom returns a flat 640 byte QSFP28 image with Rx power thresholds
+// and channel monitor masks in page 03h
+func page03Eeprom() []byte {
+	b := make([]byte, 640)
+	b[0], b[128] = 0x11, 0x11
+	p03 := 512 - 128
+
+	// Rx power thresholds: high alarm, low alarm, high warning, low warning
+	copy(b[p03+176:], []byte{0x10, 0x00, 0x00, 0x10, 0x08, 0x00, 0x00, 0x20})
+	// Rx1 normal, Rx2 low warning, Rx3 high alarm, Rx4 low alarm
+	copy(b[34:], []byte{0x04, 0x00, 0x00, 0x18, 0x20, 0x00, 0x00, 0x00})
+
+	b[p03+242] = 0x81 // Rx1 high alarm, Rx2 low warning
+	b[p03+246] = 0x04 // Tx2 low alarm
+	return b
+}
+
+func TestPage03(t *testing.T) {
+	s, err := Decode(page03Eeprom())
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if s.Page03 == nil {
+		t.Fatal("Page03 not decoded")
+	}
+	if mw := s.Page03.RxPowerThresholds.HighAlarm.MilliWatt(); mw != 0.4096 {
+		t.Errorf("Rx power high alarm = %f mW, want 0.4096 mW", mw)
+	}
+
+	want := []common.AlarmState{
+		common.AlarmStateNormal,
+		common.AlarmStateLowWarning,
+		common.AlarmStateHighAlarm,
+		common.AlarmStateLowAlarm,
+	}
+	states := s.ChannelStates()
+	if len(states) != 4 {
+		t.Fatalf("Got %d channel states, want 4", len(states))
+	}
+	for i, c := range states {
+		if c.Channel != i+1 || c.RxPower != want[i] {
+			t.Errorf("Channel %d Rx power state = %v, want %v", i+1, c.RxPower, want[i])
+		}
+		// The Tx thresholds are all zero, so there is nothing to compare
+		if c.TxBias != common.AlarmStateNone || c.TxPower != common.AlarmStateNone {
+			t.Errorf("Channel %d Tx state = %v/%v, want none", i+1, c.TxBias, c.TxPower)
+		}
+	}
+	if str := states[1].String(); str != "Rx Power Low warning" {
+		t.Errorf("Channel 2 state = %q", str)
+	}
+
+	m := s.Page03.ChannelMasks
+	if !m.RxPower(1).HighAlarm || m.RxPower(1).LowWarning || !m.RxPower(2).LowWarning || !m.TxPower(2).LowAlarm {
+		t.Errorf("Unexpected masks %+v %+v %+v", m.RxPower(1), m.RxPower(2), m.TxPower(2))
+	}
+	if l := strings.Join(m.List(), ", "); l != "Rx1 High Alarm, Rx2 Low Warning, Tx2 Low Alarm" {
+		t.Errorf("ChannelMasks.List() = %q", l)
+	}
+}
+
+func TestPage03NotPresent(t *testing.T) {
+	b := page03Eeprom()
+
+	// Decoding copies the input, later changes must not be visible
+	s, err := Decode(b)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	b[34] = 0xFF
+	if s.ChannelMonitoring.Rx1Power[0] != 0x04 {
+		t.Error("Decode aliases its input")
+	}
+
+	for name, eeprom := range map[string][]byte{
+		"512 byte dump": b[:512],
+		"zero page 03h": append(append([]byte{}, b[:512]...), make([]byte, 128)...),
+		"flat memory":   append([]byte{0x11, 0x00, 0x04}, b[3:]...),
+	} {
+		s, err := Decode(eeprom)
+		if err != nil {
+			t.Fatalf("%s: Decode failed: %v", name, err)
+		}
+		if s.Page03 != nil || s.ChannelStates() != nil {
+			t.Errorf("%s: expected no page 03h", name)
+		}
+	}
+
+	m := common.MemoryFromFlatSff8636(b[:512])
+	s, err = DecodeMemory(m)
+	if err != nil {
+		t.Fatalf("DecodeMemory failed: %v", err)
+	}
+	if s.Page03 != nil {
+		t.Error("DecodeMemory: expected no page 03h")
+	}
+	m = common.MemoryFromFlatSff8636(b)
+	if s, err = DecodeMemory(m); err != nil || s.Page03 == nil {
+		t.Errorf("DecodeMemory: expected page 03h, got err %v", err)
+	}
+}
@@ -1,12 +1,14 @@
 package sff8636
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
-	"unsafe"
+
+	"github.com/bluecmd/go-sff/common"
 )
 
 const (
@@ -53,59 +55,32 @@ const (
 	FcSpeed200Mbps    = (1 << (2 + 56))
 	FcSpeed100Mbps    = (1 << (0 + 56))
 
-	EthernetUnspecified    = 0x00
-	Ethernet100gAoc        = 0x01
-	Ethernet100gSr4        = 0x02
-	Ethernet100gLr4        = 0x03
-	Ethernet100gEr4        = 0x04
-	Ethernet100gSr10       = 0x05
-	Ethernet100gCwdm4Fec   = 0x06
-	Ethernet100gPsm4       = 0x07
-	Ethernet100gAcc        = 0x08
-	Ethernet100gCwdm4NoFec = 0x09
+	EthernetUnspecified    = common.ExtComplianceUnspecified
+	Ethernet100gAoc        = common.ExtCompliance100gAoc5e5
+	Ethernet100gSr4        = common.ExtCompliance100gBaseSr4
+	Ethernet100gLr4        = common.ExtCompliance100gBaseLr4
+	Ethernet100gEr4        = common.ExtCompliance100gBaseEr4
+	Ethernet100gSr10       = common.ExtCompliance100gBaseSr10
+	Ethernet100gCwdm4Fec   = common.ExtCompliance100gCwdm4
+	Ethernet100gPsm4       = common.ExtCompliance100gPsm4
+	Ethernet100gAcc        = common.ExtCompliance100gAcc5e5
+	Ethernet100gCwdm4NoFec = common.ExtComplianceObsolete09
 	Ethernet100gRsvd1      = 0x0A
-	Ethernet100gCr4        = 0x0B
-	Ethernet25gCrCaS       = 0x0C
-	Ethernet25gCrCaN       = 0x0D
-	Ethernet40gEr4         = 0x10
-	Ethernet4X10Sr         = 0x11
-	Ethernet40gPsm4        = 0x12
-	EthernetG959P1i1_2d1   = 0x13
-	EthernetG959P1s1_2d2   = 0x14
-	EthernetG959P1l1_2d2   = 0x15
-	Ethernet10GtSfi        = 0x16
-	Ethernet100gClr4       = 0x17
-	Ethernet100gAoc2       = 0x18
-	Ethernet100gAcc2       = 0x19
+	Ethernet100gCr4        = common.ExtCompliance100gBaseCr4
+	Ethernet25gCrCaS       = common.ExtCompliance25gBaseCrS
+	Ethernet25gCrCaN       = common.ExtCompliance25gBaseCrN
+	Ethernet40gEr4         = common.ExtCompliance40gBaseEr4
+	Ethernet4X10Sr         = common.ExtCompliance4x10gBaseSr
+	Ethernet40gPsm4        = common.ExtCompliance40gPsm4
+	EthernetG959P1i1_2d1   = common.ExtComplianceG959P1i12d1
+	EthernetG959P1s1_2d2   = common.ExtComplianceG959P1s12d2
+	EthernetG959P1l1_2d2   = common.ExtComplianceG959P1l12d2
+	Ethernet10GtSfi        = common.ExtCompliance10gBaseTSfi
+	Ethernet100gClr4       = common.ExtCompliance100gClr4
+	Ethernet100gAoc2       = common.ExtCompliance100gAoc1e12
+	Ethernet100gAcc2       = common.ExtCompliance100gAcc1e12
 )
 
-var extNames = map[byte]string{
-	EthernetUnspecified:    "Reserved or unknown",
-	Ethernet100gAoc:        "100G Ethernet: 100G AOC or 25GAUI C2M AOC with worst BER of 5x10^(-5)",
-	Ethernet100gSr4:        "100G Ethernet: 100G Base-SR4 or 25GBase-SR",
-	Ethernet100gLr4:        "100G Ethernet: 100G Base-LR4",
-	Ethernet100gEr4:        "100G Ethernet: 100G Base-ER4",
-	Ethernet100gSr10:       "100G Ethernet: 100G Base-SR10",
-	Ethernet100gCwdm4Fec:   "100G Ethernet: 100G CWDM4 MSA with FEC",
-	Ethernet100gPsm4:       "100G Ethernet: 100G PSM4 Parallel SMF",
-	Ethernet100gAcc:        "100G Ethernet: 100G ACC or 25GAUI C2M ACC with worst BER of 5x10^(-5)",
-	Ethernet100gCwdm4NoFec: "100G Ethernet: 100G CWDM4 MSA without FEC",
-	Ethernet100gRsvd1:      "Reserved or unknown",
-	Ethernet100gCr4:        "100G Ethernet: 100G Base-CR4 or 25G Base-CR CA-L",
-	Ethernet25gCrCaS:       "25G Ethernet: 25G Base-CR CA-S",
-	Ethernet25gCrCaN:       "25G Ethernet: 25G Base-CR CA-N",
-	Ethernet40gEr4:         "40G Ethernet: 40G Base-ER4",
-	Ethernet4X10Sr:         "4x10G Ethernet: 10G Base-SR",
-	Ethernet40gPsm4:        "40G Ethernet: 40G PSM4 Parallel SMF",
-	EthernetG959P1i1_2d1:   "Ethernet: G959.1 profile P1I1-2D1 (10709 MBd, 2km, 1310nm SM)",
-	EthernetG959P1s1_2d2:   "Ethernet: G959.1 profile P1S1-2D2 (10709 MBd, 40km, 1550nm SM)",
-	EthernetG959P1l1_2d2:   "Ethernet: G959.1 profile P1L1-2D2 (10709 MBd, 80km, 1550nm SM)",
-	Ethernet10GtSfi:        "10G Ethernet: 10G Base-T with SFI electrical interface",
-	Ethernet100gClr4:       "100G Ethernet: 100G CLR4",
-	Ethernet100gAoc2:       "100G Ethernet: 100G AOC or 25GAUI C2M AOC with worst BER of 10^(-12)",
-	Ethernet100gAcc2:       "100G Ethernet: 100G ACC or 25GAUI C2M ACC with worst BER of 10^(-12)",
-}
-
 var names = map[uint64]string{
 	Ethernet10gLrm:    "10G Ethernet: 10G Base-LRM",
 	Ethernet10gLr:     "10G Ethernet: 10G Base-LR",
@@ -177,8 +152,10 @@ func (t Transceiver) List() []string {
 	return r
 }
 
+// Uint64 returns the transceiver codes with byte 0 as the least significant
+// byte, matching the keys of transceiverNames
 func (t Transceiver) Uint64() uint64 {
-	return *(*uint64)(unsafe.Pointer(&t[0]))
+	return binary.LittleEndian.Uint64(t[:])
 }
 
 func (t Transceiver) String() string {
@@ -215,3 +192,13 @@ func (t *Transceiver) UnmarshalJSON(in []byte) error {
 	}
 	return nil
 }
+
+// TransceiverList returns the specification compliance codes (bytes 131-138)
+// followed by the extended specification compliance code (byte 192), if any
+func (s *Sff8636) TransceiverList() []string {
+	l := s.Transceiver.List()
+	if s.LinkCodes.IsSpecified() {
+		l = append(l, "Extended: "+s.LinkCodes.String())
+	}
+	return l
+}
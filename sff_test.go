@@ -1,11 +1,14 @@
 package sff
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/bluecmd/go-sff/common"
 	"github.com/bluecmd/go-sff/sff8079"
 )
 
@@ -28,6 +31,12 @@ func TestGetType(t *testing.T) {
 			want:    TypeSff8636,
 			wantErr: false,
 		},
+		{
+			name:    "CMIS QSFP-DD",
+			eeprom:  createCmisEeprom(),
+			want:    TypeCmis,
+			wantErr: false,
+		},
 		{
 			name:    "Unknown type",
 			eeprom:  []byte{0x00, 0x00, 0x00, 0x00},
@@ -109,6 +118,183 @@ func TestReadFromPath(t *testing.T) {
 	}
 }
 
+func TestNewEthtoolReader(t *testing.T) {
+	reader := NewEthtoolReader("eth0")
+	if reader.ifname != "eth0" {
+		t.Errorf("Expected interface eth0, got %s", reader.ifname)
+	}
+
+	// Interface names must fit in IFNAMSIZ including the terminating NUL
+	reader = NewEthtoolReader("interfacename16b")
+	if _, err := reader.Read(); err == nil {
+		t.Error("Expected error for too long interface name")
+	}
+}
+
+func TestReadShortDump(t *testing.T) {
+	b, err := os.ReadFile("testdata/TR-FC85S-N00.bin")
+	if err != nil {
+		t.Fatalf("Failed to read EEPROM file: %v", err)
+	}
+
+	// 256 byte QSFP dumps are valid, missing pages are not made up
+	if typ, err := GetType(b[:256]); err != nil || typ != TypeSff8636 {
+		t.Errorf("GetType() = %v, %v, want %v", typ, err, TypeSff8636)
+	}
+	m, err := Read(&MockReader{data: b[:256]})
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if m.Memory.Has(common.UpperPage(common.AddressA0, 0, 1)) {
+		t.Errorf("Page 01h present in a 256 byte dump: %v", m.Regions())
+	}
+
+	// Dumps ending within a half page are rejected
+	if _, err := Read(&MockReader{data: b[:300]}); err == nil {
+		t.Error("Expected error for a 300 byte dump")
+	}
+}
+
+func TestEthtoolFlatOffset(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     uint32
+		len     int
+		region  common.Region
+		want    int
+		wantErr bool
+	}{
+		{"SFP A0h", EthModuleSff8472, 512, common.LowerPage(common.AddressA0), 0, false},
+		{"SFP A2h", EthModuleSff8472, 512, common.UpperPage(common.AddressA2, 0, 0), 384, false},
+		{"SFP A2h missing", EthModuleSff8079, 256, common.LowerPage(common.AddressA2), 0, true},
+		{"SFP A2h page 01h", EthModuleSff8472, 512, common.UpperPage(common.AddressA2, 0, 1), 0, true},
+		{"QSFP page 00h", EthModuleSff8636, 256, common.UpperPage(common.AddressA0, 0, 0), 128, false},
+		{"QSFP page 03h", EthModuleSff8636, 640, common.UpperPage(common.AddressA0, 0, 3), 512, false},
+		{"QSFP page 03h missing", EthModuleSff8636, 256, common.UpperPage(common.AddressA0, 0, 3), 0, true},
+		{"QSFP A2h", EthModuleSff8436, 640, common.LowerPage(common.AddressA2), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ethtoolFlatOffset(tt.typ, tt.len, tt.region)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ethtoolFlatOffset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, common.ErrRegionNotPresent) {
+				t.Errorf("Expected ErrRegionNotPresent, got %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ethtoolFlatOffset() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadPaged(t *testing.T) {
+	for _, name := range []string{"FLEX-P.8596.02", "TR-FC85S-N00", "CMIS-QDD-400G-DR4"} {
+		t.Run(name, func(t *testing.T) {
+			path := fmt.Sprintf("testdata/%s.bin", name)
+			paged, err := ReadPaged(NewFileReader(path))
+			if err != nil {
+				t.Fatalf("ReadPaged failed: %v", err)
+			}
+
+			eeprom, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read EEPROM file %s: %v", path, err)
+			}
+			flat, err := Read(&MockReader{data: eeprom})
+			if err != nil {
+				t.Fatalf("Read failed: %v", err)
+			}
+
+			if paged.Type != flat.Type || paged.String() != flat.String() {
+				t.Errorf("Paged read differs from flat read")
+			}
+			if paged.Memory == nil || !paged.Memory.Has(common.LowerPage(common.AddressA0)) {
+				t.Errorf("Expected module memory to contain A0h lower page")
+			}
+		})
+	}
+}
+
+func TestFlatMemory(t *testing.T) {
+	b := make([]byte, 640)
+	b[0], b[128] = 0x11, 0x11
+
+	// Trailing pages that are not present are left out
+	if n := len(flatMemory(MemoryFromFlat(b[:512]))); n != 512 {
+		t.Errorf("Got %d bytes without page 03h, want 512", n)
+	}
+	if n := len(flatMemory(MemoryFromFlat(b))); n != 640 {
+		t.Errorf("Got %d bytes with page 03h, want 640", n)
+	}
+	// Missing pages in between are zero filled
+	m := MemoryFromFlat(b[:256])
+	m.Write(common.UpperPage(common.AddressA0, 0, 2), make([]byte, 128))
+	if n := len(flatMemory(m)); n != 512 {
+		t.Errorf("Got %d bytes with page 02h only, want 512", n)
+	}
+}
+
+func TestGetMemoryType(t *testing.T) {
+	m := common.NewMemory()
+	if _, err := GetMemoryType(m); !errors.Is(err, common.ErrRegionNotPresent) {
+		t.Errorf("Expected ErrRegionNotPresent for empty memory, got %v", err)
+	}
+
+	m = MemoryFromFlat(createSff8636Eeprom())
+	if typ, err := GetMemoryType(m); err != nil || typ != TypeSff8636 {
+		t.Errorf("GetMemoryType() = %v, %v, want %v", typ, err, TypeSff8636)
+	}
+}
+
+func TestModuleValidate(t *testing.T) {
+	for _, name := range []string{"FLEX-P.8596.02", "IN-Q2AY2-35", "CMIS-QDD-400G-DR4"} {
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile("testdata/" + name + ".bin")
+			if err != nil {
+				t.Fatalf("Failed to read EEPROM file: %v", err)
+			}
+			module, err := Read(&MockReader{data: data})
+			if err != nil {
+				t.Fatalf("Read failed: %v", err)
+			}
+			if err := module.Validate(); err != nil {
+				t.Fatalf("Validate() = %v, want nil", err)
+			}
+
+			// Recode the vendor name, invalidating CC_BASE
+			want := "CC_BASE"
+			switch module.Type {
+			case TypeSff8079:
+				module.Sff8079.Vendor[0] ^= 0x20
+			case TypeSff8636:
+				module.Sff8636.Vendor[0] ^= 0x20
+			case TypeCmis:
+				module.Cmis.Page00.Vendor[0] ^= 0x20
+				want = "Page 00h checksum"
+			}
+			var cerr *common.ChecksumError
+			if err := module.Validate(); !errors.As(err, &cerr) || cerr.Name != want {
+				t.Fatalf("Validate() = %v, want %s mismatch", err, want)
+			}
+
+			switch module.Type {
+			case TypeSff8079:
+				module.Sff8079.UpdateChecksums()
+			case TypeSff8636:
+				module.Sff8636.UpdateChecksums()
+			case TypeCmis:
+				module.Cmis.UpdateChecksums()
+			}
+			if err := module.Validate(); err != nil {
+				t.Errorf("Validate() after UpdateChecksums = %v, want nil", err)
+			}
+		})
+	}
+}
+
 func TestModuleString(t *testing.T) {
 	// Create a mock module
 	module := &Module{
@@ -194,6 +380,20 @@ func createSff8636Eeprom() []byte {
 	return eeprom
 }
 
+func createCmisEeprom() []byte {
+	eeprom := make([]byte, 512)
+
+	// CMIS identifier (QSFP-DD) in the lower page and upper page 00h
+	eeprom[0] = 0x18
+	eeprom[1] = 0x50
+	eeprom[128] = 0x18
+
+	// Vendor name starting at byte 129
+	copy(eeprom[129:145], "Test CMIS Vendor")
+
+	return eeprom
+}
+
 // TestStringOutputs tests both String() and StringCol() methods against actual EEPROM data files
 func TestStringOutputs(t *testing.T) {
 	testCases := []struct {
@@ -208,6 +408,7 @@ func TestStringOutputs(t *testing.T) {
 		{name: "TR-FC85S-N00"},
 		{name: "FS-DWDM-SFP10G-80"},
 		{name: "PO-HUA-SFP-10G-DWDM"},
+		{name: "CMIS-QDD-400G-DR4"},
 	}
 
 	for _, tc := range testCases {
@@ -344,3 +545,39 @@ func TestStringOutputs(t *testing.T) {
 		})
 	}
 }
+
+// TestEthtoolStringGolden tests EthtoolString() against the .ethtool golden files
+func TestEthtoolStringGolden(t *testing.T) {
+	files, err := filepath.Glob("testdata/*.bin")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("No test files: %v", err)
+	}
+
+	for _, binFile := range files {
+		name := strings.TrimSuffix(filepath.Base(binFile), ".bin")
+		t.Run(name, func(t *testing.T) {
+			module := readTestModule(t, name)
+			actual, err := module.EthtoolString()
+			if module.Type == TypeCmis {
+				if !errors.Is(err, ErrNotSupported) {
+					t.Errorf("EthtoolString() error = %v, want ErrNotSupported", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EthtoolString failed: %v", err)
+			}
+
+			// The golden files are checked against ethtool's output by
+			// hand, so a missing one is not created from the renderer
+			ethtoolFile := "testdata/" + name + ".ethtool"
+			expected, err := os.ReadFile(ethtoolFile)
+			if err != nil {
+				t.Fatalf("Failed to read expected output file %s: %v", ethtoolFile, err)
+			}
+			if actual != string(expected) {
+				t.Errorf("ethtool output mismatch for %s:\n%s", name, actual)
+			}
+		})
+	}
+}